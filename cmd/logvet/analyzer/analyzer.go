@@ -0,0 +1,441 @@
+// Package analyzer defines an Analyzer that reports misuse of the github.com/junk1tm/log package.
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const logPkg = "github.com/junk1tm/log"
+
+// Analyzer reports misuse of the github.com/junk1tm/log package.
+var Analyzer = &analysis.Analyzer{
+	Name: "logvet",
+	Doc: `report misuse of the github.com/junk1tm/log package
+
+The following problems are reported:
+  - log.Field created manually instead of using the provided functions (except in tests);
+  - duplicate keys in a single call (within the same namespace);
+  - non-constant messages, e.g. formatted with fmt.Sprintf (variable data belongs in fields);
+  - Error calls without an error field, while an err variable is in scope;
+  - keys not in snake_case (dots are allowed to separate nested keys, which may be indexes, a leading underscore is allowed).`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	// the log package itself is allowed to create fields manually.
+	if pass.Pkg.Path() == logPkg {
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{(*ast.CompositeLit)(nil), (*ast.CallExpr)(nil)}
+
+	inspect.Preorder(nodes, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.CompositeLit:
+			checkFieldLiteral(pass, node)
+		case *ast.CallExpr:
+			if key, ok := fieldKey(pass, node); ok {
+				checkKeyNaming(pass, key)
+			}
+			checkCall(pass, node)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkFieldLiteral reports composite literals of log.Field,
+// suggesting a constructor for the simplest cases, e.g. log.String for {Key: k, Kind: log.StringKind, String: v}.
+// Test files are skipped, since tests may need fields of specific kinds, e.g. to compare them or to check invalid ones.
+func checkFieldLiteral(pass *analysis.Pass, lit *ast.CompositeLit) {
+	if !isLogType(pass.TypesInfo.TypeOf(lit), "Field") {
+		return
+	}
+	if strings.HasSuffix(pass.Fset.File(lit.Pos()).Name(), "_test.go") {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     lit.Pos(),
+		End:     lit.End(),
+		Message: "log.Field must be created using the provided functions, e.g. log.String",
+	}
+	if fix, ok := fieldLiteralFix(pass, lit); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	pass.Report(diag)
+}
+
+// constructors maps field kinds to the constructors that can replace literals of the kind,
+// along with the slot holding the value.
+var constructors = map[string]struct{ slot, constructor string }{
+	"StringKind": {"String", "String"},
+	"Int64Kind":  {"Integer", "Int64"},
+	"ErrorKind":  {"Interface", "NamedError"},
+}
+
+func fieldLiteralFix(pass *analysis.Pass, lit *ast.CompositeLit) (analysis.SuggestedFix, bool) {
+	values := make(map[string]ast.Expr)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		ident, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+		values[ident.Name] = kv.Value
+	}
+
+	kind, ok := values["Kind"].(*ast.SelectorExpr)
+	if !ok || len(values) != 3 || values["Key"] == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	c, ok := constructors[kind.Sel.Name]
+	if !ok || values[c.slot] == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// reuse the qualifier of the Kind constant, so the fix respects the import name of the log package.
+	qualifier := ""
+	if x, ok := kind.X.(*ast.Ident); ok {
+		qualifier = x.Name + "."
+	}
+
+	text := qualifier + c.constructor + "(" + render(pass, values["Key"]) + ", " + render(pass, values[c.slot]) + ")"
+	return analysis.SuggestedFix{
+		Message:   "Use " + qualifier + c.constructor,
+		TextEdits: []analysis.TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: []byte(text)}},
+	}, true
+}
+
+// checkCall checks calls of logging methods (Debug, Info, Warn, Error and their Context variants)
+// and of WithFields, which accept variadic fields.
+func checkCall(pass *analysis.Pass, call *ast.CallExpr) {
+	name, msgIndex, ok := loggingCall(pass, call)
+	if !ok {
+		return
+	}
+
+	fieldsIndex := msgIndex + 1
+	if name == "WithFields" {
+		fieldsIndex = msgIndex
+	} else {
+		checkMessage(pass, call.Args[msgIndex])
+	}
+	if len(call.Args) < fieldsIndex {
+		return
+	}
+	fields := call.Args[fieldsIndex:]
+
+	checkDuplicateKeys(pass, fields)
+	if (name == "Error" || name == "ErrorContext") && call.Ellipsis == token.NoPos {
+		checkErrorField(pass, call, fields)
+	}
+}
+
+// loggingCall reports whether the call is a call of a logging method or WithFields,
+// returning the name of the function and the index of the message (or the first field for WithFields).
+func loggingCall(pass *analysis.Pass, call *ast.CallExpr) (string, int, bool) {
+	fn := calledFunc(pass, call)
+	if fn == nil {
+		return "", 0, false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params()
+	if !sig.Variadic() || !isLogType(params.At(params.Len()-1).Type().(*types.Slice).Elem(), "Field") {
+		return "", 0, false
+	}
+
+	switch name := fn.Name(); name {
+	case "Debug", "Info", "Warn", "Error":
+		if params.Len() == 2 && isString(params.At(0).Type()) {
+			return name, 0, true
+		}
+	case "DebugContext", "InfoContext", "WarnContext", "ErrorContext":
+		if params.Len() == 3 && isString(params.At(1).Type()) {
+			return name, 1, true
+		}
+	case "WithFields":
+		// both the log.WithFields function and the FieldAdder method.
+		if sig.Recv() == nil && inPackage(fn, logPkg) {
+			return name, 1, true
+		}
+		if sig.Recv() != nil && params.Len() == 1 {
+			return name, 0, true
+		}
+	}
+
+	return "", 0, false
+}
+
+func checkMessage(pass *analysis.Pass, msg ast.Expr) {
+	if tv, ok := pass.TypesInfo.Types[msg]; ok && tv.Value != nil {
+		return
+	}
+
+	if call, ok := msg.(*ast.CallExpr); ok {
+		if fn := calledFunc(pass, call); inPackage(fn, "fmt") && strings.HasPrefix(fn.Name(), "Sprint") {
+			pass.Reportf(msg.Pos(), "message should not be formatted with fmt.%s, use fields for variable data", fn.Name())
+			return
+		}
+	}
+	pass.Reportf(msg.Pos(), "message should be a constant string, use fields for variable data")
+}
+
+func checkDuplicateKeys(pass *analysis.Pass, fields []ast.Expr) {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		call, ok := field.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		var k string
+		pos := call.Pos()
+		if key, ok := fieldKey(pass, call); ok {
+			k, pos = constant.StringVal(key.value), key.pos
+		} else if fn := calledFunc(pass, call); inPackage(fn, logPkg) && fn.Name() == "Error" {
+			k = "error" // the implicit key of log.Error.
+		} else {
+			continue
+		}
+
+		if seen[k] {
+			pass.Reportf(pos, "duplicate key %q", k)
+		}
+		seen[k] = true
+
+		// the fields following a namespace are scoped to it, so they may reuse the keys of the fields before it.
+		if fn := calledFunc(pass, call); inPackage(fn, logPkg) && fn.Name() == "Namespace" {
+			seen = make(map[string]bool)
+		}
+	}
+}
+
+// checkErrorField reports Error calls without an error field if there is an err variable in scope,
+// suggesting to add log.Error(err). Without one, there is likely no error to attach.
+// Calls with fields that are not constructor calls (e.g. variables) are skipped, since they may contain one.
+func checkErrorField(pass *analysis.Pass, call *ast.CallExpr, fields []ast.Expr) {
+	if !errInScope(pass, call) {
+		return
+	}
+
+	for _, field := range fields {
+		c, ok := field.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		fn := calledFunc(pass, c)
+		if !inPackage(fn, logPkg) {
+			return
+		}
+		switch fn.Name() {
+		case "Error", "NamedError", "Errors", "Any", "Lazy", "Object", "LazyObject", "Nested":
+			// Any, Lazy and Loggable may contain an error as well.
+			return
+		}
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "Error call without an error field, e.g. log.Error(err)",
+	}
+	if fix, ok := errorFieldFix(pass, call); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	pass.Report(diag)
+}
+
+// errInScope reports whether an err variable of type error is in scope at the call.
+func errInScope(pass *analysis.Pass, call *ast.CallExpr) bool {
+	scope := pass.Pkg.Scope().Innermost(call.Pos())
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent("err", call.Pos())
+	v, ok := obj.(*types.Var)
+	return ok && types.Identical(v.Type(), types.Universe.Lookup("error").Type())
+}
+
+func errorFieldFix(pass *analysis.Pass, call *ast.CallExpr) (analysis.SuggestedFix, bool) {
+	qualifier, ok := logQualifier(pass, call)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// insert after the last argument rather than before the parenthesis, which may be preceded by a trailing comma.
+	pos := call.Args[len(call.Args)-1].End()
+	text := ", " + qualifier + "Error(err)"
+	return analysis.SuggestedFix{
+		Message:   "Add " + qualifier + "Error(err)",
+		TextEdits: []analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte(text)}},
+	}, true
+}
+
+// logQualifier returns the name the log package is imported under in the file containing the node.
+func logQualifier(pass *analysis.Pass, node ast.Node) (string, bool) {
+	for _, file := range pass.Files {
+		if file.Pos() > node.Pos() || node.Pos() >= file.End() {
+			continue
+		}
+		for _, spec := range file.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); path != logPkg {
+				continue
+			}
+			if spec.Name == nil {
+				return "log.", true
+			}
+			if spec.Name.Name == "." {
+				return "", true
+			}
+			if spec.Name.Name != "_" {
+				return spec.Name.Name + ".", true
+			}
+		}
+	}
+	return "", false
+}
+
+// snakeCase matches snake_case keys, optionally separated by dots, each part may start with an underscore, e.g. "_id".
+// Nested parts may also start with an index, e.g. "errors.0_chain", as generated by log.FlattenFields.
+var snakeCase = regexp.MustCompile(`^_?[a-z][a-z0-9]*(_[a-z0-9]+)*(\.(_?[a-z]|[0-9])[a-z0-9]*(_[a-z0-9]+)*)*$`)
+
+func checkKeyNaming(pass *analysis.Pass, key constKey) {
+	// empty keys are used by the fields returned from log.Lazy functions, since their keys are replaced.
+	k := constant.StringVal(key.value)
+	if k == "" || snakeCase.MatchString(k) {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     key.pos,
+		End:     key.end,
+		Message: "key " + strconv.Quote(k) + " should be in snake_case",
+	}
+	if fixed := toSnakeCase(k); fixed != "" && key.literal {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Rename to " + strconv.Quote(fixed),
+			TextEdits: []analysis.TextEdit{{Pos: key.pos, End: key.end, NewText: []byte(strconv.Quote(fixed))}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// toSnakeCase converts the provided key to snake_case, e.g. "userID" to "user_id" and "User-Name" to "user_name".
+// It returns an empty string if the result is still not a valid key.
+func toSnakeCase(key string) string {
+	var sb strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			// start a new word on a lower-to-upper transition and at the end of an acronym, e.g. "IDName".
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		case r == '-' || r == ' ':
+			sb.WriteByte('_')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if fixed := sb.String(); snakeCase.MatchString(fixed) {
+		return fixed
+	}
+	return ""
+}
+
+// constKey is a constant key of a Field constructor call.
+type constKey struct {
+	value    constant.Value
+	pos, end token.Pos
+	literal  bool // whether the key is a string literal, so it can be fixed in place.
+}
+
+// fieldKey returns the key of the provided log.Field constructor call, if it is a constant.
+// Constructors without a key parameter (e.g. log.Error or log.Object) are skipped.
+func fieldKey(pass *analysis.Pass, call *ast.CallExpr) (constKey, bool) {
+	fn := calledFunc(pass, call)
+	if !inPackage(fn, logPkg) {
+		return constKey{}, false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil || sig.Results().Len() != 1 || !isLogType(sig.Results().At(0).Type(), "Field") {
+		return constKey{}, false
+	}
+	if sig.Params().Len() == 0 || sig.Params().At(0).Name() != "key" || len(call.Args) == 0 {
+		return constKey{}, false
+	}
+
+	arg := call.Args[0]
+	tv, ok := pass.TypesInfo.Types[arg]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return constKey{}, false
+	}
+
+	lit, isLiteral := arg.(*ast.BasicLit)
+	return constKey{value: tv.Value, pos: arg.Pos(), end: arg.End(), literal: isLiteral && lit.Kind == token.STRING}, true
+}
+
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		fn, _ := pass.TypesInfo.Uses[fun.Sel].(*types.Func)
+		return fn
+	case *ast.Ident:
+		fn, _ := pass.TypesInfo.Uses[fun].(*types.Func)
+		return fn
+	}
+	return nil
+}
+
+// inPackage reports whether fn is declared in the package with the provided path.
+func inPackage(fn *types.Func, path string) bool {
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == path
+}
+
+// isLogType reports whether t is the named type of the log package with the provided name.
+func isLogType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == logPkg && obj.Name() == name
+}
+
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+// render returns the source code of the provided expression.
+func render(pass *analysis.Pass, expr ast.Expr) string {
+	var sb strings.Builder
+	if err := format.Node(&sb, pass.Fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/junk1tm/log"
+)
+
+func literals() {
+	_ = log.Field{Key: "foo", Kind: log.StringKind, String: "bar"} // want `log.Field must be created using the provided functions`
+	_ = log.Field{Key: "foo", Kind: log.Int64Kind, Integer: 1}     // want `log.Field must be created using the provided functions`
+	_ = log.Field{}                                                // want `log.Field must be created using the provided functions`
+	_ = []log.Field{{Key: "foo"}}                                  // want `log.Field must be created using the provided functions`
+	_ = log.String("foo", "bar")
+}
+
+func duplicateKeys(logger log.Logger) {
+	logger.Info("message", log.Int("foo", 1), log.Int("foo", 2))         // want `duplicate key "foo"`
+	log.WithFields(logger, log.Int("foo", 1), log.String("foo", "bar"))  // want `duplicate key "foo"`
+	logger.Warn("message", log.Error(nil), log.NamedError("error", nil)) // want `duplicate key "error"`
+	logger.Info("message", log.Int("foo", 1), log.Int("bar", 2))
+	logger.Info("message", log.Int("foo", 1), log.Namespace("ns"), log.Int("foo", 2))
+	logger.Info("message", log.Int("foo", 1), log.Namespace("foo")) // want `duplicate key "foo"`
+}
+
+func messages(logger log.Logger, name string) {
+	const msg = "constant"
+	logger.Info("constant")
+	logger.Info(msg)
+	logger.Info(fmt.Sprintf("hello %s", name)) // want `message should not be formatted with fmt.Sprintf`
+	logger.Debug("hello " + name)              // want `message should be a constant string`
+}
+
+func errorFields(logger log.Logger, ctxLogger log.ContextLogger, fields []log.Field) {
+	logger.Error("failed") // no err in scope, so there is no error to attach.
+
+	err := errors.New("some error")
+	logger.Error("failed", log.Int("foo", 1))              // want `Error call without an error field`
+	ctxLogger.ErrorContext(context.Background(), "failed") // want `Error call without an error field`
+	logger.Error("failed",                                 // want `Error call without an error field`
+		log.Int("foo", 1),
+	)
+	logger.Error("failed", log.Error(err))
+	logger.Error("failed", log.NamedError("cleanup_error", err))
+	logger.Error("failed", log.Any("reason", err))
+	logger.Error("failed", fields...)
+}
+
+func keys() {
+	_ = log.String("userID", "foo")     // want `key "userID" should be in snake_case`
+	_ = log.String("user-name", "foo")  // want `key "user-name" should be in snake_case`
+	_ = log.String("HTTPStatus", "foo") // want `key "HTTPStatus" should be in snake_case`
+	_ = log.String("user.id", "foo")
+	_ = log.String("request_id", "foo")
+	_ = log.String("", "foo")
+	_ = log.String("_internal", "foo")
+	_ = log.String("errors.0_chain", "foo")
+	_ = log.String("errors.0.code", "foo")
+	_ = log.String("0.code", "foo") // want `key "0.code" should be in snake_case`
+	_ = log.String("user._id", "foo")
+}
@@ -0,0 +1,8 @@
+package a
+
+import "github.com/junk1tm/log"
+
+func testLiterals() {
+	// tests may create fields manually, e.g. to compare them.
+	_ = log.Field{Key: "foo", Kind: log.StringKind, String: "bar"}
+}
@@ -0,0 +1,49 @@
+// Package log is a stub of github.com/junk1tm/log for tests.
+package log
+
+import "context"
+
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+type ContextLogger interface {
+	Logger
+	ErrorContext(ctx context.Context, msg string, fields ...Field)
+}
+
+type Loggable interface {
+	ToLog() []Field
+}
+
+type Field struct {
+	Key       string
+	Kind      Kind
+	Integer   int64
+	String    string
+	Interface interface{}
+}
+
+type Kind uint8
+
+const (
+	InvalidKind Kind = iota
+	Int64Kind
+	StringKind
+	ErrorKind
+	NamespaceKind
+)
+
+func Int(key string, value int) Field         { return Field{Key: key, Kind: Int64Kind, Integer: int64(value)} }
+func Int64(key string, value int64) Field     { return Field{Key: key, Kind: Int64Kind, Integer: value} }
+func String(key, value string) Field          { return Field{Key: key, Kind: StringKind, String: value} }
+func Error(err error) Field                   { return NamedError("error", err) }
+func NamedError(key string, err error) Field  { return Field{Key: key, Kind: ErrorKind, Interface: err} }
+func Any(key string, value interface{}) Field { return Field{Key: key, Interface: value} }
+func Object(l Loggable) Field                 { return Field{Interface: l} }
+func Namespace(key string) Field              { return Field{Key: key, Kind: NamespaceKind} }
+
+func WithFields(logger Logger, fields ...Field) Logger { return logger }
@@ -0,0 +1,13 @@
+// Command logvet reports misuse of the github.com/junk1tm/log package.
+// It can be run standalone or as a vet tool:
+//
+//	go vet -vettool=$(which logvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/junk1tm/log/cmd/logvet/analyzer"
+)
+
+func main() { singlechecker.Main(analyzer.Analyzer) }
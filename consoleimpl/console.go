@@ -0,0 +1,160 @@
+// Package consoleimpl contains a dependency-free human-friendly implementation of Logger interface.
+// It is intended for local development, use jsonimpl or logfmtimpl in production.
+package consoleimpl
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/junk1tm/log"
+)
+
+// Option configures the Logger created by NewLogger.
+type Option func(*logger)
+
+// TimeFormat sets the layout used for the entry's time and Time fields (default: "15:04:05.000").
+// An empty layout disables the time annotation.
+func TimeFormat(layout string) Option { return func(l *logger) { l.timeFormat = layout } }
+
+// Color forces colored output on or off.
+// By default, colors are enabled only if w is a terminal, the NO_COLOR environment variable is empty
+// and the TERM environment variable is not "dumb".
+func Color(enabled bool) Option { return func(l *logger) { l.color = enabled } }
+
+// NewLogger creates a new log.Logger that writes each entry to w in a human-friendly form:
+// time, colored level, message and dimmed fields in "key=value" form.
+// Errors with multi-line messages and stack traces are printed below the entry, indented.
+// It is safe for concurrent use as long as w is not used by anyone else.
+func NewLogger(w io.Writer, opts ...Option) log.Logger {
+	l := &logger{
+		w:          w,
+		timeFormat: "15:04:05.000",
+		color:      isTerminal(w),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// ANSI escape codes.
+const (
+	reset   = "\x1b[0m"
+	dim     = "\x1b[2m"
+	red     = "\x1b[31m"
+	green   = "\x1b[32m"
+	yellow  = "\x1b[33m"
+	magenta = "\x1b[35m"
+)
+
+type logger struct {
+	mu         sync.Mutex // protects w.
+	w          io.Writer
+	timeFormat string
+	color      bool
+}
+
+func (l *logger) Debug(msg string, fields ...log.Field) { l.log(magenta, "DBG", msg, fields) }
+func (l *logger) Info(msg string, fields ...log.Field)  { l.log(green, "INF", msg, fields) }
+func (l *logger) Warn(msg string, fields ...log.Field)  { l.log(yellow, "WRN", msg, fields) }
+func (l *logger) Error(msg string, fields ...log.Field) { l.log(red, "ERR", msg, fields) }
+
+func (l *logger) log(color, badge, msg string, fields []log.Field) {
+	now := time.Now()
+
+	var sb strings.Builder
+	if l.timeFormat != "" {
+		l.colorize(&sb, dim, now.Format(l.timeFormat))
+		sb.WriteByte(' ')
+	}
+	l.colorize(&sb, color, badge)
+	sb.WriteByte(' ')
+	sb.WriteString(msg)
+
+	var multiline []log.Field
+	for _, field := range log.FlattenKeys(fields) {
+		value := l.formatValue(field)
+		if (field.Kind == log.ErrorKind || field.Kind == log.StackKind) && strings.Contains(value, "\n") {
+			multiline = append(multiline, field)
+			continue
+		}
+		sb.WriteByte(' ')
+		l.colorize(&sb, dim, field.Key+"=")
+		sb.WriteString(quote(value))
+	}
+	sb.WriteByte('\n')
+
+	for _, field := range multiline {
+		l.colorize(&sb, color, "  "+field.Key+":")
+		sb.WriteByte('\n')
+		for _, line := range strings.Split(l.formatValue(field), "\n") {
+			sb.WriteString("    ")
+			sb.WriteString(line)
+			sb.WriteByte('\n')
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.w, sb.String())
+}
+
+func (l *logger) colorize(sb *strings.Builder, color, s string) {
+	if !l.color {
+		sb.WriteString(s)
+		return
+	}
+	sb.WriteString(color)
+	sb.WriteString(s)
+	sb.WriteString(reset)
+}
+
+func (l *logger) formatValue(field log.Field) string {
+	return field.ValueString(l.timeFormatOrDefault())
+}
+
+func (l *logger) timeFormatOrDefault() string {
+	if l.timeFormat == "" {
+		return time.RFC3339Nano
+	}
+	return l.timeFormat
+}
+
+// quote quotes the provided value if it is empty or contains spaces, quotes or non-printable characters.
+func quote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || !strconv.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
+
+// isTerminal reports whether colored output should be enabled for w.
+func isTerminal(w io.Writer) bool {
+	// see https://no-color.org, only a non-empty value disables colors.
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,60 @@
+package consoleimpl_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/consoleimpl"
+	"github.com/junk1tm/log/logconformance"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := consoleimpl.NewLogger(&buf, consoleimpl.TimeFormat(""), consoleimpl.Color(true))
+	logger.Error("could not do something",
+		log.String("foo", "bar baz"),
+		log.Error(errors.New("first line\nsecond line")),
+		log.NamedError("cause", nil),
+	)
+
+	want := "\x1b[31mERR\x1b[0m could not do something \x1b[2mfoo=\x1b[0m\"bar baz\" \x1b[2mcause=\x1b[0m<nil>\n" +
+		"\x1b[31m  error:\x1b[0m\n" +
+		"    first line\n" +
+		"    second line\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestLogger_autoColor(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	defer r.Close()
+
+	// a pipe is not a terminal, so colors must be disabled.
+	logger := consoleimpl.NewLogger(w, consoleimpl.TimeFormat(""))
+	logger.Info("test")
+	_ = w.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	if want := "INF test\n"; string(got) != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		return consoleimpl.NewLogger(w, consoleimpl.TimeFormat(time.RFC3339))
+	}, logconformance.SkipCaller())
+}
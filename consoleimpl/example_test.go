@@ -0,0 +1,25 @@
+package consoleimpl_test
+
+import (
+	"os"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/consoleimpl"
+)
+
+func ExampleNewLogger() {
+	// configure the logger here:
+	logger := consoleimpl.NewLogger(os.Stdout, consoleimpl.TimeFormat(""), consoleimpl.Color(false))
+
+	logger.Debug("example 1", log.Int("foo", 1))
+	logger.Info("example 2", log.Int("bar", 2))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
+
+	// output:
+	// DBG example 1 foo=1
+	// INF example 2 bar=2
+	// WRN example 3 baz=3
+	// ERR example 4 qux=4
+}
@@ -0,0 +1,161 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultLogger is returned by FromContext if the context carries no Logger.
+// It is Nop by default, so logging is disabled unless a Logger is attached to the context.
+// This behaviour can be customized by setting DefaultLogger to some user-defined Logger.
+var DefaultLogger = Nop
+
+type contextKey struct{}
+
+// NewContext returns a copy of the provided context that carries the provided Logger.
+// Use FromContext to retrieve it.
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the Logger carried by the provided context.
+// If there is none, DefaultLogger is returned.
+func FromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return logger
+	}
+	return DefaultLogger
+}
+
+// ContextWithFields returns a copy of the provided context,
+// whose Logger adds the provided fields on each logging operation.
+// It is a shortcut for NewContext(ctx, WithFields(FromContext(ctx), fields...)),
+// so the fields accumulate as the context is passed down the call stack.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	return NewContext(ctx, WithFields(FromContext(ctx), fields...))
+}
+
+// ContextLogger is an extension for Logger with context-aware methods.
+// On each logging operation, it runs the registered ContextExtractor functions
+// and adds the fields they return to the provided ones.
+// Use NewContextLogger to create a ContextLogger from any Logger.
+type ContextLogger interface {
+	Logger
+	// DebugContext is like Debug, but also logs the fields extracted from the context.
+	DebugContext(ctx context.Context, msg string, fields ...Field)
+	// InfoContext is like Info, but also logs the fields extracted from the context.
+	InfoContext(ctx context.Context, msg string, fields ...Field)
+	// WarnContext is like Warn, but also logs the fields extracted from the context.
+	WarnContext(ctx context.Context, msg string, fields ...Field)
+	// ErrorContext is like Error, but also logs the fields extracted from the context.
+	ErrorContext(ctx context.Context, msg string, fields ...Field)
+}
+
+// ContextExtractor extracts fields from the provided context,
+// e.g. a request ID, a tenant ID or trace/span IDs.
+// It should return nil if the context does not carry the values it is looking for.
+type ContextExtractor func(ctx context.Context) []Field
+
+var (
+	extractorsMu sync.Mutex   // serializes writers.
+	extractors   atomic.Value // []*registeredExtractor, replaced on each write.
+)
+
+// registeredExtractor gives each registration an identity, since functions are not comparable.
+type registeredExtractor struct {
+	extract ContextExtractor
+}
+
+// RegisterContextExtractor registers the provided ContextExtractor,
+// which will be used by all ContextLogger methods.
+// Extractors run in the order they have been registered.
+// It is usually called from an init function or at the beginning of main.
+// The returned function unregisters the extractor, e.g. at the end of a test;
+// calling it more than once has no effect.
+func RegisterContextExtractor(extractor ContextExtractor) (unregister func()) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	old, _ := extractors.Load().([]*registeredExtractor)
+	registered := make([]*registeredExtractor, len(old), len(old)+1)
+	copy(registered, old)
+
+	re := &registeredExtractor{extract: extractor}
+	extractors.Store(append(registered, re))
+
+	return func() { unregisterContextExtractor(re) }
+}
+
+func unregisterContextExtractor(re *registeredExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	old, _ := extractors.Load().([]*registeredExtractor)
+	registered := make([]*registeredExtractor, 0, len(old))
+	for _, r := range old {
+		if r != re {
+			registered = append(registered, r)
+		}
+	}
+
+	extractors.Store(registered)
+}
+
+// NewContextLogger creates a ContextLogger from the provided Logger.
+// If the Logger already implements ContextLogger, it is returned as is.
+func NewContextLogger(logger Logger) ContextLogger {
+	if cl, ok := logger.(ContextLogger); ok {
+		return cl
+	}
+
+	return &contextLogger{logger: addCallerSkip(logger, 1)}
+}
+
+type contextLogger struct {
+	logger Logger
+}
+
+func (cl *contextLogger) Debug(msg string, fields ...Field) { cl.logger.Debug(msg, fields...) }
+func (cl *contextLogger) Info(msg string, fields ...Field)  { cl.logger.Info(msg, fields...) }
+func (cl *contextLogger) Warn(msg string, fields ...Field)  { cl.logger.Warn(msg, fields...) }
+func (cl *contextLogger) Error(msg string, fields ...Field) { cl.logger.Error(msg, fields...) }
+
+func (cl *contextLogger) DebugContext(ctx context.Context, msg string, fields ...Field) {
+	cl.logger.Debug(msg, extractFields(ctx, fields)...)
+}
+
+func (cl *contextLogger) InfoContext(ctx context.Context, msg string, fields ...Field) {
+	cl.logger.Info(msg, extractFields(ctx, fields)...)
+}
+
+func (cl *contextLogger) WarnContext(ctx context.Context, msg string, fields ...Field) {
+	cl.logger.Warn(msg, extractFields(ctx, fields)...)
+}
+
+func (cl *contextLogger) ErrorContext(ctx context.Context, msg string, fields ...Field) {
+	cl.logger.Error(msg, extractFields(ctx, fields)...)
+}
+
+func (cl *contextLogger) WithCallerSkip(skip int) Logger {
+	return &contextLogger{logger: addCallerSkip(cl.logger, skip)}
+}
+
+func (cl *contextLogger) Enabled(lvl Level) bool { return Enabled(cl.logger, lvl) }
+
+func (cl *contextLogger) Unwrap() Logger { return cl.logger }
+
+// extractFields returns the fields extracted from the context followed by the provided fields.
+func extractFields(ctx context.Context, fields []Field) []Field {
+	registered, _ := extractors.Load().([]*registeredExtractor)
+	if len(registered) == 0 {
+		return fields
+	}
+
+	var result []Field
+	for _, re := range registered {
+		result = append(result, re.extract(ctx)...)
+	}
+
+	return append(result, fields...)
+}
@@ -0,0 +1,90 @@
+package log_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/junk1tm/log"
+)
+
+func TestFromContext(t *testing.T) {
+	if got := log.FromContext(context.Background()); got != log.DefaultLogger {
+		t.Errorf("got %v; want %v", got, log.DefaultLogger)
+	}
+
+	var spy spyLogger
+	ctx := log.NewContext(context.Background(), &spy)
+	ctx = log.ContextWithFields(ctx, log.Int("foo", 1))
+	ctx = log.ContextWithFields(ctx, log.Int("bar", 2))
+
+	log.FromContext(ctx).Info("first call", log.Int("baz", 3))
+
+	want := []call{
+		{
+			msg:    "first call",
+			fields: []log.Field{log.Int("foo", 1), log.Int("bar", 2), log.Int("baz", 3), log.String("caller", "context_test.go:21")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestContextLogger(t *testing.T) {
+	type requestIDKey struct{}
+
+	t.Cleanup(log.RegisterContextExtractor(func(ctx context.Context) []log.Field {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return []log.Field{log.String("request_id", id)}
+		}
+		return nil
+	}))
+
+	var spy spyLogger
+	logger := log.NewContextLogger(&spy)
+	if got := log.NewContextLogger(logger); got != logger {
+		t.Errorf("got %v; want %v", got, logger)
+	}
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+	logger.InfoContext(ctx, "first call", log.Int("foo", 1))
+	logger.ErrorContext(context.Background(), "second call", log.Int("bar", 2))
+
+	want := []call{
+		{
+			msg:    "first call",
+			fields: []log.Field{log.String("request_id", "abc"), log.Int("foo", 1), log.String("caller", "context_test.go:51")},
+		},
+		{
+			msg:    "second call",
+			fields: []log.Field{log.Int("bar", 2), log.String("caller", "context_test.go:52")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	first := log.RegisterContextExtractor(func(context.Context) []log.Field { return []log.Field{log.Int("first", 1)} })
+	second := log.RegisterContextExtractor(func(context.Context) []log.Field { return []log.Field{log.Int("second", 2)} })
+	defer second()
+
+	var spy spyLogger
+	logger := log.NewContextLogger(&spy)
+
+	first()
+	first() // must be a no-op.
+	logger.InfoContext(context.Background(), "first call")
+
+	want := []call{
+		{
+			msg:    "first call",
+			fields: []log.Field{log.Int("second", 2), log.String("caller", "context_test.go:79")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,428 @@
+package log
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a log context general field.
+// The available Field producing functions are the only supported way to create it, since they ensure type safety.
+// A manually created Field with no Kind causes panic on Logger's methods calls (see FlattenFields),
+// while one whose Kind does not match the populated value slot is silently logged with a wrong value.
+//
+//	// bad: no compile time checks, Kind and the value slot may not match.
+//	log.Field{Key: "foo", Kind: log.StringKind, String: "bar"}
+//	// good: the compiler ensures type safety, impossible to accidentally pass an invalid type.
+//	log.String("foo", "bar")
+//
+// To avoid allocations, Field stores its value in one of the slots depending on its Kind,
+// so constructing fields of the most common types does not allocate.
+// Logger implementations should switch on Kind and use the corresponding accessor.
+type Field struct {
+	Key       string
+	Kind      Kind
+	Integer   int64       // integers, floats (as IEEE 754 bits), bools, durations and times (as Unix nanoseconds).
+	String    string      // strings.
+	Interface interface{} // everything else: errors, Loggable, slices, time locations, etc.
+}
+
+// Kind indicates the type of a Field's value.
+type Kind uint8
+
+const (
+	InvalidKind Kind = iota // the zero value, reported for manually created fields.
+	IntKind
+	Int8Kind
+	Int16Kind
+	Int32Kind
+	Int64Kind
+	UintKind
+	Uint8Kind
+	Uint16Kind
+	Uint32Kind
+	Uint64Kind
+	Float32Kind
+	Float64Kind
+	BoolKind
+	StringKind
+	TimeKind
+	DurationKind
+	ErrorKind
+	ObjectKind
+	StringsKind
+	IntsKind
+	ByteStringKind
+	BinaryKind
+	StringerKind
+	UintptrKind
+	Complex128Kind
+	DurationsKind
+	TimesKind
+	AnyKind
+	NestedKind
+	NamespaceKind
+	LazyKind
+	StackKind
+	ErrorsKind
+)
+
+var kindNames = [...]string{
+	InvalidKind:  "Invalid",
+	IntKind:      "Int",
+	Int8Kind:     "Int8",
+	Int16Kind:    "Int16",
+	Int32Kind:    "Int32",
+	Int64Kind:    "Int64",
+	UintKind:     "Uint",
+	Uint8Kind:    "Uint8",
+	Uint16Kind:   "Uint16",
+	Uint32Kind:   "Uint32",
+	Uint64Kind:   "Uint64",
+	Float32Kind:  "Float32",
+	Float64Kind:  "Float64",
+	BoolKind:     "Bool",
+	StringKind:   "String",
+	TimeKind:     "Time",
+	DurationKind: "Duration",
+	ErrorKind:    "Error",
+	ObjectKind:   "Object",
+
+	StringsKind:    "Strings",
+	IntsKind:       "Ints",
+	ByteStringKind: "ByteString",
+	BinaryKind:     "Binary",
+	StringerKind:   "Stringer",
+	UintptrKind:    "Uintptr",
+	Complex128Kind: "Complex128",
+	DurationsKind:  "Durations",
+	TimesKind:      "Times",
+	AnyKind:        "Any",
+	NestedKind:     "Nested",
+	NamespaceKind:  "Namespace",
+	LazyKind:       "Lazy",
+	StackKind:      "Stack",
+	ErrorsKind:     "Errors",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+func Int(key string, value int) Field                { return integer(key, IntKind, int64(value)) }
+func Int8(key string, value int8) Field              { return integer(key, Int8Kind, int64(value)) }
+func Int16(key string, value int16) Field            { return integer(key, Int16Kind, int64(value)) }
+func Int32(key string, value int32) Field            { return integer(key, Int32Kind, int64(value)) }
+func Int64(key string, value int64) Field            { return integer(key, Int64Kind, value) }
+func Uint(key string, value uint) Field              { return integer(key, UintKind, int64(value)) }
+func Uint8(key string, value uint8) Field            { return integer(key, Uint8Kind, int64(value)) }
+func Uint16(key string, value uint16) Field          { return integer(key, Uint16Kind, int64(value)) }
+func Uint32(key string, value uint32) Field          { return integer(key, Uint32Kind, int64(value)) }
+func Uint64(key string, value uint64) Field          { return integer(key, Uint64Kind, int64(value)) }
+func Float32(key string, value float32) Field        { return integer(key, Float32Kind, float32Bits(value)) }
+func Float64(key string, value float64) Field        { return integer(key, Float64Kind, float64Bits(value)) }
+func Bool(key string, value bool) Field              { return integer(key, BoolKind, boolToInt(value)) }
+func String(key, value string) Field                 { return Field{Key: key, Kind: StringKind, String: value} }
+func Duration(key string, value time.Duration) Field { return integer(key, DurationKind, int64(value)) }
+func Error(err error) Field                          { return NamedError("error", err) }
+func Object(l Loggable) Field                        { return Field{Key: "", Kind: ObjectKind, Interface: l} }
+
+func NamedError(key string, err error) Field            { return boxed(key, ErrorKind, err) }
+func Errors(key string, errs []error) Field             { return boxed(key, ErrorsKind, errs) }
+func Strings(key string, value []string) Field          { return boxed(key, StringsKind, value) }
+func Ints(key string, value []int) Field                { return boxed(key, IntsKind, value) }
+func ByteString(key string, value []byte) Field         { return boxed(key, ByteStringKind, value) }
+func Binary(key string, value []byte) Field             { return boxed(key, BinaryKind, value) }
+func Stringer(key string, value fmt.Stringer) Field     { return boxed(key, StringerKind, value) }
+func Uintptr(key string, value uintptr) Field           { return integer(key, UintptrKind, int64(value)) }
+func Complex128(key string, value complex128) Field     { return boxed(key, Complex128Kind, value) }
+func Durations(key string, value []time.Duration) Field { return boxed(key, DurationsKind, value) }
+func Times(key string, value []time.Time) Field         { return boxed(key, TimesKind, value) }
+
+// Nested creates a Field that keeps the fields of the provided Loggable under the key,
+// unlike Object, which splices them into the parent.
+// Implementations that support nesting (e.g. JSON) encode it as a nested object,
+// the others use dotted keys, e.g. "user.id" (see FlattenKeys).
+func Nested(key string, l Loggable) Field { return boxed(key, NestedKind, l) }
+
+// Namespace creates a Field that nests all the following fields under the key.
+// Like Nested, it is encoded either as a nested object or using dotted keys.
+func Namespace(key string) Field { return Field{Key: key, Kind: NamespaceKind} }
+
+// Lazy creates a Field whose value is computed by the provided function
+// only when the entry is actually written, i.e. never for disabled levels.
+// The key of the computed Field is replaced with the provided one.
+// NOTE: when passed to WithFields, it may be evaluated only once, when the child Logger is created (see FieldAdder).
+func Lazy(key string, fn func() Field) Field { return boxed(key, LazyKind, fn) }
+
+// LazyObject is like Object, but the Loggable is computed by the provided function
+// only when the entry is actually written (see Lazy).
+func LazyObject(fn func() Loggable) Field {
+	return Lazy("", func() Field { return Object(fn()) })
+}
+
+// Stack creates a Field with the stack trace of the current goroutine, starting from the caller.
+// The stack trace is stored as a multi-line string, so implementations can treat it like String.
+func Stack(key string) Field { return Field{Key: key, Kind: StackKind, String: stacktrace(2)} }
+
+func integer(key string, kind Kind, i int64) Field { return Field{Key: key, Kind: kind, Integer: i} }
+func boxed(key string, kind Kind, v interface{}) Field {
+	return Field{Key: key, Kind: kind, Interface: v}
+}
+
+func float32Bits(f float32) int64 { return int64(math.Float32bits(f)) }
+func float64Bits(f float64) int64 { return int64(math.Float64bits(f)) }
+
+// stacktrace formats the stack of the current goroutine, skipping the provided number of callers,
+// the same way runtime/debug.Stack does, but without the goroutine header.
+func stacktrace(skip int) string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			return sb.String()
+		}
+		sb.WriteByte('\n')
+	}
+}
+
+// stringerValue calls the String method of the provided fmt.Stringer, returning "<nil>" for a nil value
+// or a nil pointer whose method panics, the same way fmt does.
+func stringerValue(s fmt.Stringer) (str string) {
+	if s == nil {
+		return "<nil>"
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if v := reflect.ValueOf(s); v.Kind() == reflect.Ptr && v.IsNil() {
+				str = "<nil>"
+				return
+			}
+			panic(r)
+		}
+	}()
+	return s.String()
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// the range of times representable as int64 Unix nanoseconds.
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+func Time(key string, value time.Time) Field {
+	if value.Before(minTime) || value.After(maxTime) {
+		// rare case, fall back to storing the whole value.
+		return Field{Key: key, Kind: TimeKind, Interface: value}
+	}
+	return Field{Key: key, Kind: TimeKind, Integer: value.UnixNano(), Interface: value.Location()}
+}
+
+// Any creates a Field from a value of an arbitrary type.
+// If the type is supported by one of the typed Field producing functions, the value is passed to it,
+// otherwise the resulting Field has AnyKind and the value is left to the implementation to encode.
+// It is an escape hatch, the typed functions should be preferred where possible.
+func Any(key string, value interface{}) Field {
+	switch v := value.(type) {
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int8(key, v)
+	case int16:
+		return Int16(key, v)
+	case int32:
+		return Int32(key, v)
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint(key, v)
+	case uint8:
+		return Uint8(key, v)
+	case uint16:
+		return Uint16(key, v)
+	case uint32:
+		return Uint32(key, v)
+	case uint64:
+		return Uint64(key, v)
+	case uintptr:
+		return Uintptr(key, v)
+	case float32:
+		return Float32(key, v)
+	case float64:
+		return Float64(key, v)
+	case complex128:
+		return Complex128(key, v)
+	case bool:
+		return Bool(key, v)
+	case string:
+		return String(key, v)
+	case []string:
+		return Strings(key, v)
+	case []int:
+		return Ints(key, v)
+	case []byte:
+		return Binary(key, v)
+	case time.Time:
+		return Time(key, v)
+	case []time.Time:
+		return Times(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case []time.Duration:
+		return Durations(key, v)
+	case error:
+		return NamedError(key, v)
+	case []error:
+		return Errors(key, v)
+	case fmt.Stringer:
+		return Stringer(key, v)
+	default:
+		return boxed(key, AnyKind, v)
+	}
+}
+
+// Bool returns the value of a BoolKind field.
+func (f Field) Bool() bool { return f.Integer == 1 }
+
+// Float32 returns the value of a Float32Kind field.
+func (f Field) Float32() float32 { return math.Float32frombits(uint32(f.Integer)) }
+
+// Float64 returns the value of a Float64Kind field.
+func (f Field) Float64() float64 { return math.Float64frombits(uint64(f.Integer)) }
+
+// Duration returns the value of a DurationKind field.
+func (f Field) Duration() time.Duration { return time.Duration(f.Integer) }
+
+// Time returns the value of a TimeKind field.
+func (f Field) Time() time.Time {
+	switch v := f.Interface.(type) {
+	case time.Time:
+		return v
+	case *time.Location:
+		return time.Unix(0, f.Integer).In(v)
+	default:
+		return time.Unix(0, f.Integer)
+	}
+}
+
+// Value returns the value of the field as interface{}, e.g. int for IntKind.
+// It allocates, so implementations should prefer switching on Kind,
+// but it's handy for the ones that accept arbitrary values anyway.
+func (f Field) Value() interface{} {
+	switch f.Kind {
+	case IntKind:
+		return int(f.Integer)
+	case Int8Kind:
+		return int8(f.Integer)
+	case Int16Kind:
+		return int16(f.Integer)
+	case Int32Kind:
+		return int32(f.Integer)
+	case Int64Kind:
+		return f.Integer
+	case UintKind:
+		return uint(f.Integer)
+	case Uint8Kind:
+		return uint8(f.Integer)
+	case Uint16Kind:
+		return uint16(f.Integer)
+	case Uint32Kind:
+		return uint32(f.Integer)
+	case Uint64Kind:
+		return uint64(f.Integer)
+	case Float32Kind:
+		return f.Float32()
+	case Float64Kind:
+		return f.Float64()
+	case BoolKind:
+		return f.Bool()
+	case StringKind, StackKind:
+		return f.String
+	case TimeKind:
+		return f.Time()
+	case DurationKind:
+		return f.Duration()
+	case UintptrKind:
+		return uintptr(f.Integer)
+	default:
+		return f.Interface
+	}
+}
+
+// ValueString returns the value of the field formatted as text, e.g. "42" for IntKind,
+// using the provided layout for times. Slices are formatted like fmt.Sprint does, e.g. "[a b]",
+// and a nil error or fmt.Stringer as "<nil>". It is intended for Logger implementations with text-based output.
+// ValueString panics for Object, Lazy, Nested and Namespace fields, which must be flattened first (see FlattenKeys).
+func (f Field) ValueString(timeLayout string) string {
+	switch f.Kind {
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		return strconv.FormatInt(f.Integer, 10)
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind, UintptrKind:
+		return strconv.FormatUint(uint64(f.Integer), 10)
+	case Float32Kind:
+		return strconv.FormatFloat(float64(f.Float32()), 'g', -1, 32)
+	case Float64Kind:
+		return strconv.FormatFloat(f.Float64(), 'g', -1, 64)
+	case BoolKind:
+		return strconv.FormatBool(f.Bool())
+	case StringKind, StackKind:
+		return f.String
+	case TimeKind:
+		return f.Time().Format(timeLayout)
+	case DurationKind:
+		return f.Duration().String()
+	case ErrorKind:
+		err, _ := f.Interface.(error)
+		if err == nil {
+			return "<nil>"
+		}
+		return err.Error()
+	case ErrorsKind, StringsKind, IntsKind, DurationsKind:
+		return fmt.Sprint(f.Interface)
+	case ByteStringKind:
+		return string(f.Interface.([]byte))
+	case BinaryKind:
+		return base64.StdEncoding.EncodeToString(f.Interface.([]byte))
+	case StringerKind:
+		s, _ := f.Interface.(fmt.Stringer)
+		return stringerValue(s)
+	case Complex128Kind:
+		return strconv.FormatComplex(f.Interface.(complex128), 'g', -1, 128)
+	case TimesKind:
+		values := f.Interface.([]time.Time)
+		formatted := make([]string, len(values))
+		for i, t := range values {
+			formatted[i] = t.Format(timeLayout)
+		}
+		return fmt.Sprint(formatted)
+	case AnyKind:
+		return fmt.Sprintf("%+v", f.Interface)
+	default:
+		panic(fmt.Sprintf("unexpected field kind %v", f.Kind))
+	}
+}
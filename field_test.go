@@ -0,0 +1,190 @@
+package log_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+)
+
+func TestField_Value(t *testing.T) {
+	now := time.Now()
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	err := errors.New("some error")
+
+	tests := []struct {
+		field log.Field
+		kind  log.Kind
+		want  interface{}
+	}{
+		{log.Int("", -1), log.IntKind, -1},
+		{log.Int8("", math.MinInt8), log.Int8Kind, int8(math.MinInt8)},
+		{log.Int16("", math.MinInt16), log.Int16Kind, int16(math.MinInt16)},
+		{log.Int32("", math.MinInt32), log.Int32Kind, int32(math.MinInt32)},
+		{log.Int64("", math.MinInt64), log.Int64Kind, int64(math.MinInt64)},
+		{log.Uint("", 1), log.UintKind, uint(1)},
+		{log.Uint8("", math.MaxUint8), log.Uint8Kind, uint8(math.MaxUint8)},
+		{log.Uint16("", math.MaxUint16), log.Uint16Kind, uint16(math.MaxUint16)},
+		{log.Uint32("", math.MaxUint32), log.Uint32Kind, uint32(math.MaxUint32)},
+		{log.Uint64("", math.MaxUint64), log.Uint64Kind, uint64(math.MaxUint64)},
+		{log.Float32("", -0.5), log.Float32Kind, float32(-0.5)},
+		{log.Float64("", math.MaxFloat64), log.Float64Kind, math.MaxFloat64},
+		{log.Bool("", true), log.BoolKind, true},
+		{log.Bool("", false), log.BoolKind, false},
+		{log.String("", "foo"), log.StringKind, "foo"},
+		{log.Time("", now.In(loc)), log.TimeKind, now.Round(0).In(loc)},
+		{log.Time("", time.Time{}), log.TimeKind, time.Time{}},
+		{log.Duration("", time.Second), log.DurationKind, time.Second},
+		{log.Error(err), log.ErrorKind, err},
+		{log.Object(A{a: 1}), log.ObjectKind, A{a: 1}},
+		{log.Strings("", []string{"a"}), log.StringsKind, []string{"a"}},
+		{log.Ints("", []int{1}), log.IntsKind, []int{1}},
+		{log.ByteString("", []byte("a")), log.ByteStringKind, []byte("a")},
+		{log.Binary("", []byte{1}), log.BinaryKind, []byte{1}},
+		{log.Stringer("", time.Second), log.StringerKind, time.Second},
+		{log.Uintptr("", 1), log.UintptrKind, uintptr(1)},
+		{log.Complex128("", 1+2i), log.Complex128Kind, 1 + 2i},
+		{log.Durations("", []time.Duration{time.Second}), log.DurationsKind, []time.Duration{time.Second}},
+		{log.Times("", []time.Time{{}}), log.TimesKind, []time.Time{{}}},
+		{log.Any("", struct{}{}), log.AnyKind, struct{}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			if got := tt.field.Kind; got != tt.kind {
+				t.Errorf("got %v; want %v", got, tt.kind)
+			}
+			if got := tt.field.Value(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAny(t *testing.T) {
+	err := errors.New("some error")
+
+	tests := []struct {
+		value interface{}
+		want  log.Field
+	}{
+		{1, log.Int("key", 1)},
+		{uint8(1), log.Uint8("key", 1)},
+		{1.5, log.Float64("key", 1.5)},
+		{true, log.Bool("key", true)},
+		{"foo", log.String("key", "foo")},
+		{[]string{"foo"}, log.Strings("key", []string{"foo"})},
+		{[]byte("foo"), log.Binary("key", []byte("foo"))},
+		{time.Second, log.Duration("key", time.Second)},
+		{err, log.NamedError("key", err)},
+		{[]error{err}, log.Errors("key", []error{err})},
+		{A{a: 1}, log.Field{Key: "key", Kind: log.AnyKind, Interface: A{a: 1}}},
+	}
+
+	for _, tt := range tests {
+		if got := log.Any("key", tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("got %+v; want %+v", got, tt.want)
+		}
+	}
+}
+
+func TestField_ValueString(t *testing.T) {
+	tm := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		field log.Field
+		want  string
+	}{
+		{log.Int("", -1), "-1"},
+		{log.Uint64("", math.MaxUint64), "18446744073709551615"},
+		{log.Float32("", 0.1), "0.1"},
+		{log.Float64("", 1e21), "1e+21"},
+		{log.Bool("", true), "true"},
+		{log.String("", "foo"), "foo"},
+		{log.Time("", tm), "2021-11-01T12:00:00Z"},
+		{log.Duration("", time.Second), "1s"},
+		{log.Error(errors.New("some error")), "some error"},
+		{log.Error(nil), "<nil>"},
+		{log.Errors("", []error{errors.New("a"), nil}), "[a <nil>]"},
+		{log.Strings("", []string{"a", "b"}), "[a b]"},
+		{log.Ints("", []int{1, 2}), "[1 2]"},
+		{log.ByteString("", []byte("foo")), "foo"},
+		{log.Binary("", []byte("foo")), "Zm9v"},
+		{log.Stringer("", time.Minute), "1m0s"},
+		{log.Stringer("", nil), "<nil>"},
+		{log.Stringer("", (*pointerStringer)(nil)), "<nil>"},
+		{log.Uintptr("", 1), "1"},
+		{log.Complex128("", 1+2i), "(1+2i)"},
+		{log.Durations("", []time.Duration{time.Second}), "[1s]"},
+		{log.Times("", []time.Time{tm}), "[2021-11-01T12:00:00Z]"},
+		{log.Any("", struct{ A int }{1}), "{A:1}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field.Kind.String(), func(t *testing.T) {
+			if got := tt.field.ValueString(time.RFC3339); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// pointerStringer is a fmt.Stringer whose method panics on a nil receiver.
+type pointerStringer struct{ s string }
+
+func (ps *pointerStringer) String() string { return ps.s }
+
+func TestStack(t *testing.T) {
+	field := log.Stack("stack")
+	if field.Kind != log.StackKind {
+		t.Fatalf("got %v; want %v", field.Kind, log.StackKind)
+	}
+
+	first := strings.SplitN(field.String, "\n", 3)
+	if len(first) < 2 || first[0] != "github.com/junk1tm/log_test.TestStack" || !strings.Contains(first[1], "field_test.go:") {
+		t.Errorf("got %q; want the stack trace to start from the caller", field.String)
+	}
+}
+
+// legacyField is the previous Field representation, kept for benchmarks.
+type legacyField struct {
+	Key   string
+	Value interface{}
+}
+
+var (
+	fieldSink  []log.Field
+	legacySink []legacyField
+)
+
+func BenchmarkFields(b *testing.B) {
+	now := time.Now()
+
+	b.Run("interface", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			legacySink = []legacyField{
+				{"int", 1000 + i},
+				{"float64", float64(i) / 3},
+				{"duration", time.Duration(i)},
+				{"time", now},
+			}
+		}
+	})
+
+	b.Run("tagged", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fieldSink = []log.Field{
+				log.Int("int", 1000+i),
+				log.Float64("float64", float64(i)/3),
+				log.Duration("duration", time.Duration(i)),
+				log.Time("time", now),
+			}
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package jsonimpl_test
+
+import (
+	"os"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/jsonimpl"
+)
+
+func ExampleNewLogger() {
+	// configure the logger here:
+	logger := jsonimpl.NewLogger(os.Stdout, jsonimpl.TimeKey(""))
+
+	logger.Debug("example 1", log.Int("foo", 1))
+	logger.Info("example 2", log.Int("bar", 2))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
+
+	// output:
+	// {"level":"debug","msg":"example 1","foo":1}
+	// {"level":"info","msg":"example 2","bar":2}
+	// {"level":"warn","msg":"example 3","baz":3}
+	// {"level":"error","msg":"example 4","qux":4}
+}
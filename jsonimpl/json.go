@@ -0,0 +1,241 @@
+// Package jsonimpl contains a dependency-free JSON implementation of Logger interface.
+package jsonimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/junk1tm/log"
+)
+
+// Option configures the Logger created by NewLogger.
+type Option func(*logger)
+
+// TimeKey sets the key used for the entry's time (default: "time").
+// An empty key disables the time annotation.
+func TimeKey(key string) Option { return func(l *logger) { l.timeKey = key } }
+
+// TimeFormat sets the layout used for the entry's time and Time fields (default: time.RFC3339Nano).
+func TimeFormat(layout string) Option { return func(l *logger) { l.timeFormat = layout } }
+
+// LevelKey sets the key used for the entry's level (default: "level").
+func LevelKey(key string) Option { return func(l *logger) { l.levelKey = key } }
+
+// MessageKey sets the key used for the entry's message (default: "msg").
+func MessageKey(key string) Option { return func(l *logger) { l.messageKey = key } }
+
+// NewLogger creates a new log.Logger that writes each entry to w as a single line of JSON.
+// It is safe for concurrent use as long as w is not used by anyone else.
+func NewLogger(w io.Writer, opts ...Option) log.Logger {
+	l := &logger{
+		w:          w,
+		timeKey:    "time",
+		timeFormat: time.RFC3339Nano,
+		levelKey:   "level",
+		messageKey: "msg",
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+type logger struct {
+	mu         sync.Mutex // protects w.
+	w          io.Writer
+	timeKey    string
+	timeFormat string
+	levelKey   string
+	messageKey string
+}
+
+func (l *logger) Debug(msg string, fields ...log.Field) { l.log(log.DebugLevel, msg, fields) }
+func (l *logger) Info(msg string, fields ...log.Field)  { l.log(log.InfoLevel, msg, fields) }
+func (l *logger) Warn(msg string, fields ...log.Field)  { l.log(log.WarnLevel, msg, fields) }
+func (l *logger) Error(msg string, fields ...log.Field) { l.log(log.ErrorLevel, msg, fields) }
+
+var bufPool = sync.Pool{
+	New: func() interface{} { return new([]byte) },
+}
+
+func (l *logger) log(lvl log.Level, msg string, fields []log.Field) {
+	now := time.Now()
+
+	bufp := bufPool.Get().(*[]byte)
+	defer bufPool.Put(bufp)
+
+	buf := append((*bufp)[:0], '{')
+	if l.timeKey != "" {
+		buf = appendKey(buf, l.timeKey)
+		buf = appendString(buf, now.Format(l.timeFormat))
+	}
+	buf = appendKey(buf, l.levelKey)
+	buf = appendString(buf, lvl.String())
+	buf = appendKey(buf, l.messageKey)
+	buf = appendString(buf, msg)
+
+	buf = l.appendFields(buf, log.FlattenFields(fields))
+	buf = append(buf, '}', '\n')
+	*bufp = buf
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(buf)
+}
+
+// appendFields appends the provided flattened fields as members of the current object.
+// A Namespace field opens a nested object that contains all the following fields.
+func (l *logger) appendFields(buf []byte, fields []log.Field) []byte {
+	for i, field := range fields {
+		buf = appendKey(buf, field.Key)
+		if field.Kind == log.NamespaceKind {
+			buf = append(buf, '{')
+			buf = l.appendFields(buf, fields[i+1:])
+			return append(buf, '}')
+		}
+		buf = l.appendValue(buf, field)
+	}
+	return buf
+}
+
+func (l *logger) appendValue(buf []byte, field log.Field) []byte {
+	switch field.Kind {
+	case log.IntKind, log.Int8Kind, log.Int16Kind, log.Int32Kind, log.Int64Kind:
+		return strconv.AppendInt(buf, field.Integer, 10)
+	case log.UintKind, log.Uint8Kind, log.Uint16Kind, log.Uint32Kind, log.Uint64Kind, log.UintptrKind:
+		return strconv.AppendUint(buf, uint64(field.Integer), 10)
+	case log.Float32Kind:
+		return appendFloat(buf, float64(field.Float32()), 32)
+	case log.Float64Kind:
+		return appendFloat(buf, field.Float64(), 64)
+	case log.BoolKind:
+		return strconv.AppendBool(buf, field.Bool())
+	case log.ErrorKind:
+		err, _ := field.Interface.(error)
+		if err == nil {
+			return append(buf, "null"...)
+		}
+		return appendString(buf, err.Error())
+	case log.ErrorsKind:
+		values := field.Interface.([]error)
+		return appendArray(buf, len(values), func(buf []byte, i int) []byte {
+			if values[i] == nil {
+				return append(buf, "null"...)
+			}
+			return appendString(buf, values[i].Error())
+		})
+	case log.StringsKind:
+		values := field.Interface.([]string)
+		return appendArray(buf, len(values), func(buf []byte, i int) []byte { return appendString(buf, values[i]) })
+	case log.IntsKind:
+		values := field.Interface.([]int)
+		return appendArray(buf, len(values), func(buf []byte, i int) []byte { return strconv.AppendInt(buf, int64(values[i]), 10) })
+	case log.DurationsKind:
+		values := field.Interface.([]time.Duration)
+		return appendArray(buf, len(values), func(buf []byte, i int) []byte { return appendString(buf, values[i].String()) })
+	case log.TimesKind:
+		values := field.Interface.([]time.Time)
+		return appendArray(buf, len(values), func(buf []byte, i int) []byte {
+			return appendString(buf, values[i].Format(l.timeFormat))
+		})
+	case log.AnyKind:
+		b, err := json.Marshal(field.Interface)
+		if err != nil {
+			return appendString(buf, fmt.Sprintf("%+v", field.Interface))
+		}
+		return append(buf, b...)
+	case log.NestedKind:
+		buf = append(buf, '{')
+		buf = l.appendFields(buf, log.FlattenFields(field.Interface.(log.Loggable).ToLog()))
+		return append(buf, '}')
+	default:
+		// strings, times, durations, etc. are encoded as JSON strings.
+		return appendString(buf, field.ValueString(l.timeFormat))
+	}
+}
+
+// appendArray appends a JSON array of n elements, each appended by the provided function.
+func appendArray(buf []byte, n int, appendElem func(buf []byte, i int) []byte) []byte {
+	buf = append(buf, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendElem(buf, i)
+	}
+	return append(buf, ']')
+}
+
+// appendKey appends the provided key preceded by a comma if needed.
+func appendKey(buf []byte, key string) []byte {
+	if buf[len(buf)-1] != '{' {
+		buf = append(buf, ',')
+	}
+	buf = appendString(buf, key)
+	return append(buf, ':')
+}
+
+// appendFloat appends the provided float the same way encoding/json does.
+// Since JSON has no representation for NaN and infinities, they are encoded as strings.
+func appendFloat(buf []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(buf, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(buf, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(buf, `"-Inf"`...)
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) || bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	return strconv.AppendFloat(buf, f, format, -1, bitSize)
+}
+
+const hex = "0123456789abcdef"
+
+// appendString appends the provided string as a quoted JSON string.
+// Invalid UTF-8 sequences are replaced with U+FFFD.
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf = append(buf, '\\', c)
+			case c == '\n':
+				buf = append(buf, '\\', 'n')
+			case c == '\r':
+				buf = append(buf, '\\', 'r')
+			case c == '\t':
+				buf = append(buf, '\\', 't')
+			case c < 0x20:
+				buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				buf = append(buf, c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, "\ufffd"...)
+		} else {
+			buf = append(buf, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(buf, '"')
+}
@@ -0,0 +1,141 @@
+package jsonimpl_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/jsonimpl"
+	"github.com/junk1tm/log/logconformance"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := jsonimpl.NewLogger(&buf,
+		jsonimpl.TimeKey("ts"),
+		jsonimpl.TimeFormat(time.RFC3339),
+		jsonimpl.LevelKey("lvl"),
+		jsonimpl.MessageKey("message"),
+	)
+
+	tm := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	logger.Info("\"quoted\"\n\tmessage\x01 \xff",
+		log.Int("int", -1),
+		log.Int8("int8", -8),
+		log.Int16("int16", -16),
+		log.Int32("int32", -32),
+		log.Int64("int64", math.MinInt64),
+		log.Uint("uint", 1),
+		log.Uint8("uint8", 8),
+		log.Uint16("uint16", 16),
+		log.Uint32("uint32", 32),
+		log.Uint64("uint64", math.MaxUint64),
+		log.Float32("float32", 0.5),
+		log.Float64("float64", 1e21),
+		log.Float64("nan", math.NaN()),
+		log.Bool("bool", true),
+		log.String("string", "日本語"),
+		log.Time("time", tm),
+		log.Duration("duration", time.Second),
+		log.Error(errors.New("some error")),
+		log.Object(object{}),
+		log.Strings("strings", []string{"a", "b"}),
+		log.Ints("ints", []int{1, 2}),
+		log.ByteString("bytestring", []byte("foo")),
+		log.Binary("binary", []byte("foo")),
+		log.Stringer("stringer", time.Minute),
+		log.Uintptr("uintptr", 1),
+		log.Complex128("complex128", 1+2i),
+		log.Durations("durations", []time.Duration{time.Second}),
+		log.Times("times", []time.Time{tm}),
+		log.Any("any", map[string]int{"foo": 1}),
+		log.Any("any_invalid", func() {}),
+		log.NamedError("named_error", errors.New("named error")),
+		log.NamedError("nil_error", nil),
+		log.Errors("errors", []error{errors.New("first"), nil}),
+		log.Stack("stack"),
+		log.Nested("obj", object{}),
+		log.Namespace("ns"),
+		log.Int("last", 1),
+	)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("got %v; want valid JSON: %s", err, buf.String())
+	}
+	if _, err := time.Parse(time.RFC3339, got["ts"].(string)); err != nil {
+		t.Errorf("got %v; want RFC3339 time", err)
+	}
+	delete(got, "ts")
+	if stack, _ := got["stack"].(string); !strings.Contains(stack, "jsonimpl_test.TestLogger") {
+		t.Errorf("got %q; want a stack trace", stack)
+	}
+	delete(got, "stack")
+	if _, ok := got["any_invalid"].(string); ok {
+		got["any_invalid"] = "PLACEHOLDER" // the address of a func is not stable.
+	}
+
+	want := map[string]interface{}{
+		"lvl":      "info",
+		"message":  "\"quoted\"\n\tmessage\x01 �",
+		"int":      -1.0,
+		"int8":     -8.0,
+		"int16":    -16.0,
+		"int32":    -32.0,
+		"int64":    float64(math.MinInt64),
+		"uint":     1.0,
+		"uint8":    8.0,
+		"uint16":   16.0,
+		"uint32":   32.0,
+		"uint64":   float64(math.MaxUint64),
+		"float32":  0.5,
+		"float64":  1e21,
+		"nan":      "NaN",
+		"bool":     true,
+		"string":   "日本語",
+		"time":     "2021-11-01T12:00:00Z",
+		"duration": "1s",
+		"error":    "some error",
+		"nested":   "value",
+
+		"strings":     []interface{}{"a", "b"},
+		"ints":        []interface{}{1.0, 2.0},
+		"bytestring":  "foo",
+		"binary":      "Zm9v",
+		"stringer":    "1m0s",
+		"uintptr":     1.0,
+		"complex128":  "(1+2i)",
+		"durations":   []interface{}{"1s"},
+		"times":       []interface{}{"2021-11-01T12:00:00Z"},
+		"any":         map[string]interface{}{"foo": 1.0},
+		"any_invalid": "PLACEHOLDER",
+		"named_error": "named error",
+		"nil_error":   nil,
+		"errors":      []interface{}{"first", nil},
+		"obj":         map[string]interface{}{"nested": "value"},
+		"ns":          map[string]interface{}{"last": 1.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+type object struct{}
+
+func (object) ToLog() []log.Field {
+	return []log.Field{log.String("nested", "value")}
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		return jsonimpl.NewLogger(w)
+	}, logconformance.SkipCaller())
+}
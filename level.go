@@ -0,0 +1,117 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// String returns a lower-case ASCII representation of the level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the same values as ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = lvl
+	return nil
+}
+
+// ParseLevel parses a level from its text representation (case-insensitive).
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("unknown level %q", s)
+	}
+}
+
+// AtomicLevel is a Level that can be safely changed at runtime from multiple goroutines.
+// Reading the level requires no locks, so it can be used on the hot path.
+// The zero value is an AtomicLevel set to INFO level.
+// Use WithAtomicLevel to filter a Logger using an AtomicLevel.
+type AtomicLevel struct {
+	lvl int32
+}
+
+// NewAtomicLevel creates a new AtomicLevel set to the provided level.
+func NewAtomicLevel(lvl Level) *AtomicLevel {
+	return &AtomicLevel{lvl: int32(lvl)}
+}
+
+// Level returns the current level.
+func (al *AtomicLevel) Level() Level { return Level(atomic.LoadInt32(&al.lvl)) }
+
+// SetLevel changes the current level.
+func (al *AtomicLevel) SetLevel(lvl Level) { atomic.StoreInt32(&al.lvl, int32(lvl)) }
+
+// Enabled reports whether the provided level is enabled, i.e. it is not below the current level.
+func (al *AtomicLevel) Enabled(lvl Level) bool { return lvl >= al.Level() }
+
+// ServeHTTP implements http.Handler, allowing to get and change the current level over HTTP.
+// GET returns the current level as JSON, e.g. {"level":"info"}.
+// PUT changes the current level, the request body must be in the same form.
+// Any other method results in 405 Method Not Allowed.
+func (al *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	type payload struct {
+		Level *Level `json:"level,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+
+	reply := func(code int, p payload) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(p)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		lvl := al.Level()
+		reply(http.StatusOK, payload{Level: &lvl})
+	case http.MethodPut:
+		var req payload
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			reply(http.StatusBadRequest, payload{Error: fmt.Sprintf("could not decode request: %v", err)})
+			return
+		}
+		if req.Level == nil {
+			reply(http.StatusBadRequest, payload{Error: "level must be specified"})
+			return
+		}
+		al.SetLevel(*req.Level)
+		reply(http.StatusOK, payload{Level: req.Level})
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		reply(http.StatusMethodNotAllowed, payload{Error: "only GET and PUT are supported"})
+	}
+}
@@ -0,0 +1,121 @@
+package log_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/junk1tm/log"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, lvl := range []log.Level{log.DebugLevel, log.InfoLevel, log.WarnLevel, log.ErrorLevel} {
+		got, err := log.ParseLevel(strings.ToUpper(lvl.String()))
+		if err != nil {
+			t.Fatalf("got %v; want no error", err)
+		}
+		if got != lvl {
+			t.Errorf("got %v; want %v", got, lvl)
+		}
+	}
+
+	if _, err := log.ParseLevel("fatal"); err == nil {
+		t.Errorf("want an error")
+	}
+}
+
+func TestAtomicLevel(t *testing.T) {
+	var al log.AtomicLevel
+	if got := al.Level(); got != log.InfoLevel {
+		t.Errorf("got %v; want %v", got, log.InfoLevel)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			al.SetLevel(log.ErrorLevel)
+			_ = al.Enabled(log.DebugLevel)
+		}()
+	}
+	wg.Wait()
+
+	if al.Enabled(log.WarnLevel) {
+		t.Errorf("want WARN level to be disabled")
+	}
+	if !al.Enabled(log.ErrorLevel) {
+		t.Errorf("want ERROR level to be enabled")
+	}
+}
+
+func TestAtomicLevel_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		wantCode int
+		wantBody string
+		wantLvl  log.Level
+	}{
+		{
+			name:     "get",
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+			wantBody: `{"level":"info"}`,
+			wantLvl:  log.InfoLevel,
+		},
+		{
+			name:     "put",
+			method:   http.MethodPut,
+			body:     `{"level":"debug"}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"level":"debug"}`,
+			wantLvl:  log.DebugLevel,
+		},
+		{
+			name:     "put (unknown level)",
+			method:   http.MethodPut,
+			body:     `{"level":"fatal"}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"could not decode request: unknown level \"fatal\""}`,
+			wantLvl:  log.InfoLevel,
+		},
+		{
+			name:     "put (no level)",
+			method:   http.MethodPut,
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"level must be specified"}`,
+			wantLvl:  log.InfoLevel,
+		},
+		{
+			name:     "post",
+			method:   http.MethodPost,
+			wantCode: http.StatusMethodNotAllowed,
+			wantBody: `{"error":"only GET and PUT are supported"}`,
+			wantLvl:  log.InfoLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			al := log.NewAtomicLevel(log.InfoLevel)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			al.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.wantCode {
+				t.Errorf("got %d; want %d", got, tt.wantCode)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.wantBody {
+				t.Errorf("got %s; want %s", got, tt.wantBody)
+			}
+			if got := al.Level(); got != tt.wantLvl {
+				t.Errorf("got %v; want %v", got, tt.wantLvl)
+			}
+		})
+	}
+}
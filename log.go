@@ -1,10 +1,6 @@
 // Package log provides a general-purpose logging API.
 package log
 
-import (
-	"time"
-)
-
 // Logger is a minimal logging interface with levels.
 // It uses structured logging with Field parameters to ensure type safety.
 type Logger interface {
@@ -14,6 +10,9 @@ type Logger interface {
 	// Info writes logs at INFO level.
 	// It is used to log information useful for users.
 	Info(msg string, fields ...Field)
+	// Warn writes logs at WARN level.
+	// It is used to log abnormal conditions that are not errors.
+	Warn(msg string, fields ...Field)
 	// Error writes logs at ERROR level.
 	// It is used to handle errors by logging them.
 	Error(msg string, fields ...Field)
@@ -27,40 +26,6 @@ type Loggable interface {
 	ToLog() []Field
 }
 
-// Field is a log context general field.
-// It must not be created manually, doing so will cause panic on Logger's methods calls.
-// Instead, available Field producing functions should be used, which ensure type safety.
-//
-//  // bad: Value is interface{}, no compile time checks.
-//  log.Field{Key: "foo", Value: "bar"}
-//  // good: the compiler ensures type safety, impossible to accidentally pass an invalid type.
-//  log.String("foo", "bar")
-//
-type Field struct {
-	Key   string
-	Value interface{}
-	valid bool // a non-exported flag that indicates if this Field has been created properly.
-}
-
-func Int(key string, value int) Field                { return Field{key, value, true} }
-func Int8(key string, value int8) Field              { return Field{key, value, true} }
-func Int16(key string, value int16) Field            { return Field{key, value, true} }
-func Int32(key string, value int32) Field            { return Field{key, value, true} }
-func Int64(key string, value int64) Field            { return Field{key, value, true} }
-func Uint(key string, value uint) Field              { return Field{key, value, true} }
-func Uint8(key string, value uint8) Field            { return Field{key, value, true} }
-func Uint16(key string, value uint16) Field          { return Field{key, value, true} }
-func Uint32(key string, value uint32) Field          { return Field{key, value, true} }
-func Uint64(key string, value uint64) Field          { return Field{key, value, true} }
-func Float32(key string, value float32) Field        { return Field{key, value, true} }
-func Float64(key string, value float64) Field        { return Field{key, value, true} }
-func Bool(key string, value bool) Field              { return Field{key, value, true} }
-func String(key, value string) Field                 { return Field{key, value, true} }
-func Time(key string, value time.Time) Field         { return Field{key, value, true} }
-func Duration(key string, value time.Duration) Field { return Field{key, value, true} }
-func Error(err error) Field                          { return Field{"error", err, true} }
-func Object(l Loggable) Field                        { return Field{"", l, true} }
-
 // Nop is a no-op Logger implementation useful in tests.
 var Nop Logger = &nop{}
 
@@ -68,22 +33,72 @@ type nop struct{}
 
 func (n *nop) Debug(msg string, fields ...Field) {}
 func (n *nop) Info(msg string, fields ...Field)  {}
+func (n *nop) Warn(msg string, fields ...Field)  {}
 func (n *nop) Error(msg string, fields ...Field) {}
 
 // callerSkipper is an optional extension for Logger.
 // It allows implementations to increase the number of callers skipped by caller annotation.
+// WithCallerSkip must return a new Logger, leaving the original one untouched,
+// so that deriving a child never affects its parent or siblings.
 type callerSkipper interface {
-	AddCallerSkip(skip int)
+	WithCallerSkip(skip int) Logger
+}
+
+// addCallerSkip returns a copy of the provided Logger that skips additional callers, if supported.
+// Otherwise, the Logger is returned as is.
+func addCallerSkip(logger Logger, skip int) Logger {
+	if skipper, ok := logger.(callerSkipper); ok {
+		return skipper.WithCallerSkip(skip)
+	}
+	return logger
+}
+
+// LevelEnabler is an optional extension for Logger.
+// It allows implementations to report whether the provided level is enabled,
+// so that the work of preparing entries that would be dropped (e.g. evaluating Lazy fields) can be skipped.
+type LevelEnabler interface {
+	// Enabled reports whether logging operations at the provided level are written.
+	Enabled(lvl Level) bool
+}
+
+// Enabled reports whether logging operations at the provided level are written by the Logger.
+// It walks the chain of wrappers (see Unwrap methods) until it finds a LevelEnabler;
+// if there is none, the level is considered enabled.
+func Enabled(logger Logger, lvl Level) bool {
+	for {
+		switch l := logger.(type) {
+		case LevelEnabler:
+			return l.Enabled(lvl)
+		case interface{ Unwrap() Logger }:
+			logger = l.Unwrap()
+		default:
+			return true
+		}
+	}
+}
+
+// FieldAdder is an optional extension for Logger.
+// It allows implementations to create a child Logger with the provided fields natively,
+// e.g. by encoding the fields once instead of on each logging operation.
+// Such implementations take a snapshot of the fields: Object, Nested and Lazy fields
+// are evaluated once when the child is created, so later changes to their values are not logged.
+type FieldAdder interface {
+	// WithFields returns a new Logger that adds the provided fields on each logging operation.
+	// It must leave the original Logger untouched.
+	WithFields(fields ...Field) Logger
 }
 
 // WithFields creates a child Logger that adds the provided fields on each logging operation.
+// If the provided Logger implements FieldAdder, the child is created natively,
+// and the fields may be evaluated only once (see FieldAdder).
+// Otherwise, Object, Nested and Lazy fields are evaluated on each logging operation.
 func WithFields(logger Logger, fields ...Field) Logger {
-	if skipper, ok := logger.(callerSkipper); ok {
-		skipper.AddCallerSkip(1)
+	if adder, ok := logger.(FieldAdder); ok {
+		return adder.WithFields(fields...)
 	}
 
 	return &withFields{
-		logger: logger,
+		logger: addCallerSkip(logger, 1),
 		fields: fields,
 	}
 }
@@ -101,16 +116,25 @@ func (wf *withFields) Info(msg string, fields ...Field) {
 	wf.logger.Info(msg, append(wf.copyFields(), fields...)...)
 }
 
+func (wf *withFields) Warn(msg string, fields ...Field) {
+	wf.logger.Warn(msg, append(wf.copyFields(), fields...)...)
+}
+
 func (wf *withFields) Error(msg string, fields ...Field) {
 	wf.logger.Error(msg, append(wf.copyFields(), fields...)...)
 }
 
-func (wf *withFields) AddCallerSkip(skip int) {
-	if skipper, ok := wf.logger.(callerSkipper); ok {
-		skipper.AddCallerSkip(skip)
-	}
+// WithFields merges the provided fields with the existing ones, avoiding another level of wrapping.
+func (wf *withFields) WithFields(fields ...Field) Logger {
+	return &withFields{logger: wf.logger, fields: append(wf.copyFields(), fields...)}
+}
+
+func (wf *withFields) WithCallerSkip(skip int) Logger {
+	return &withFields{logger: addCallerSkip(wf.logger, skip), fields: wf.fields}
 }
 
+func (wf *withFields) Enabled(lvl Level) bool { return Enabled(wf.logger, lvl) }
+
 func (wf *withFields) Unwrap() Logger { return wf.logger }
 
 func (wf *withFields) copyFields() []Field {
@@ -125,9 +149,67 @@ type Level int
 const (
 	DebugLevel Level = iota - 1
 	InfoLevel
+	WarnLevel
 	ErrorLevel
 )
 
+// WithLevel creates a child Logger that drops logging operations below the provided minimum level.
+// The check happens before passing the call further, so the fields of dropped calls are never processed.
+func WithLevel(logger Logger, min Level) Logger {
+	return WithAtomicLevel(logger, NewAtomicLevel(min))
+}
+
+// WithAtomicLevel is like WithLevel, but the minimum level is read from the provided AtomicLevel
+// on each logging operation, so it can be changed at runtime.
+func WithAtomicLevel(logger Logger, min *AtomicLevel) Logger {
+	return &withLevel{
+		logger: addCallerSkip(logger, 1),
+		min:    min,
+	}
+}
+
+type withLevel struct {
+	logger Logger
+	min    *AtomicLevel
+}
+
+func (wl *withLevel) Debug(msg string, fields ...Field) {
+	if wl.min.Enabled(DebugLevel) {
+		wl.logger.Debug(msg, fields...)
+	}
+}
+
+func (wl *withLevel) Info(msg string, fields ...Field) {
+	if wl.min.Enabled(InfoLevel) {
+		wl.logger.Info(msg, fields...)
+	}
+}
+
+func (wl *withLevel) Warn(msg string, fields ...Field) {
+	if wl.min.Enabled(WarnLevel) {
+		wl.logger.Warn(msg, fields...)
+	}
+}
+
+func (wl *withLevel) Error(msg string, fields ...Field) {
+	if wl.min.Enabled(ErrorLevel) {
+		wl.logger.Error(msg, fields...)
+	}
+}
+
+// WithFields pushes the provided fields down the chain, so they are not processed for dropped calls.
+func (wl *withLevel) WithFields(fields ...Field) Logger {
+	return &withLevel{logger: WithFields(wl.logger, fields...), min: wl.min}
+}
+
+func (wl *withLevel) WithCallerSkip(skip int) Logger {
+	return &withLevel{logger: addCallerSkip(wl.logger, skip), min: wl.min}
+}
+
+func (wl *withLevel) Enabled(lvl Level) bool { return wl.min.Enabled(lvl) && Enabled(wl.logger, lvl) }
+
+func (wl *withLevel) Unwrap() Logger { return wl.logger }
+
 // Hook is a callback function to be executed before a logging operation.
 type Hook func(lvl Level, msg string, fields []Field) error
 
@@ -141,12 +223,8 @@ var OnHookError = func(logger Logger, err error) {
 // WithHooks creates a child Logger that executes the provided hooks on each logging operation.
 // If a hook returns an error, it will be handled by OnHookError.
 func WithHooks(logger Logger, hooks ...Hook) Logger {
-	if skipper, ok := logger.(callerSkipper); ok {
-		skipper.AddCallerSkip(1)
-	}
-
 	return &withHooks{
-		logger: logger,
+		logger: addCallerSkip(logger, 1),
 		hooks:  hooks,
 	}
 }
@@ -166,17 +244,22 @@ func (wh *withHooks) Info(msg string, fields ...Field) {
 	wh.logger.Info(msg, fields...)
 }
 
+func (wh *withHooks) Warn(msg string, fields ...Field) {
+	wh.execHooks(WarnLevel, msg, fields)
+	wh.logger.Warn(msg, fields...)
+}
+
 func (wh *withHooks) Error(msg string, fields ...Field) {
 	wh.execHooks(ErrorLevel, msg, fields)
 	wh.logger.Error(msg, fields...)
 }
 
-func (wh *withHooks) AddCallerSkip(skip int) {
-	if skipper, ok := wh.logger.(callerSkipper); ok {
-		skipper.AddCallerSkip(skip)
-	}
+func (wh *withHooks) WithCallerSkip(skip int) Logger {
+	return &withHooks{logger: addCallerSkip(wh.logger, skip), hooks: wh.hooks}
 }
 
+func (wh *withHooks) Enabled(lvl Level) bool { return Enabled(wh.logger, lvl) }
+
 func (wh *withHooks) Unwrap() Logger { return wh.logger }
 
 func (wh *withHooks) execHooks(lvl Level, msg string, fields []Field) {
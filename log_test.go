@@ -45,8 +45,8 @@ func TestWithHooks(t *testing.T) {
 	// multiplies each int value by 2.
 	multiplierHook := func(lvl log.Level, msg string, fields []log.Field) error {
 		for i := range fields {
-			if v, ok := fields[i].Value.(int); ok {
-				fields[i].Value = v * 2
+			if fields[i].Kind == log.IntKind {
+				fields[i].Integer *= 2
 			}
 		}
 		return nil
@@ -124,35 +124,145 @@ func TestIssue11(t *testing.T) {
 	}
 }
 
+// creating children must affect neither their parent nor their siblings.
+func TestChildren(t *testing.T) {
+	var spy spyLogger
+	parent := log.WithFields(&spy, log.Int("foo", 1))
+	child1 := log.WithFields(parent, log.Int("bar", 2))
+	child2 := log.WithLevel(log.WithHooks(parent), log.InfoLevel)
+
+	parent.Info("first call")
+	child1.Info("second call")
+	child2.Info("third call")
+
+	want := []call{
+		{
+			msg:    "first call",
+			fields: []log.Field{log.Int("foo", 1), log.String("caller", "log_test.go:134")},
+		},
+		{
+			msg:    "second call",
+			fields: []log.Field{log.Int("foo", 1), log.Int("bar", 2), log.String("caller", "log_test.go:135")},
+		},
+		{
+			msg:    "third call",
+			fields: []log.Field{log.Int("foo", 1), log.String("caller", "log_test.go:136")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestWithLevel(t *testing.T) {
+	var spy spyLogger
+	logger := log.WithLevel(&spy, log.WarnLevel)
+	logger = log.WithFields(logger, log.String("key", "value"))
+
+	logger.Debug("first call")
+	logger.Info("second call")
+	logger.Warn("third call")
+	logger.Error("fourth call")
+
+	want := []call{
+		{
+			msg:    "third call",
+			fields: []log.Field{log.String("key", "value"), log.String("caller", "log_test.go:164")},
+		},
+		{
+			msg:    "fourth call",
+			fields: []log.Field{log.String("key", "value"), log.String("caller", "log_test.go:165")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	if err := log.NamedLevels.Load("billing=error"); err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	defer func() { _ = log.NamedLevels.Load("") }()
+
+	var spy spyLogger
+	if !log.Enabled(&spy, log.DebugLevel) {
+		t.Error("want levels to be enabled by default")
+	}
+
+	logger := log.WithLevel(enablerLogger{Logger: &spy, min: log.InfoLevel}, log.DebugLevel)
+	logger = log.WithHooks(log.WithFields(logger, log.Int("foo", 1)))
+	logger = log.NewContextLogger(logger)
+
+	tests := []struct {
+		logger log.Logger
+		want   map[log.Level]bool
+	}{
+		{
+			logger: logger,
+			want:   map[log.Level]bool{log.DebugLevel: false, log.InfoLevel: true, log.ErrorLevel: true},
+		},
+		{
+			logger: log.WithLevel(logger, log.WarnLevel),
+			want:   map[log.Level]bool{log.DebugLevel: false, log.InfoLevel: false, log.ErrorLevel: true},
+		},
+		{
+			logger: log.Named(logger, "billing"),
+			want:   map[log.Level]bool{log.DebugLevel: false, log.WarnLevel: false, log.ErrorLevel: true},
+		},
+	}
+
+	for _, tt := range tests {
+		for lvl, want := range tt.want {
+			if got := log.Enabled(tt.logger, lvl); got != want {
+				t.Errorf("%v: got %t; want %t", lvl, got, want)
+			}
+		}
+	}
+}
+
+// enablerLogger is a Logger that implements log.LevelEnabler.
+type enablerLogger struct {
+	log.Logger
+	min log.Level
+}
+
+func (el enablerLogger) Enabled(lvl log.Level) bool { return lvl >= el.min }
+
 type call struct {
 	msg    string
 	fields []log.Field
 }
 
 // spyLogger records its calls for later inspection in tests.
+// Loggers derived via WithCallerSkip record their calls to the original spyLogger.
 type spyLogger struct {
 	calls      []call
 	callerSkip int
+	parent     *spyLogger
 }
 
-func (sl *spyLogger) Debug(msg string, fields ...log.Field) {
-	sl.calls = append(sl.calls, call{msg: msg, fields: append(fields, sl.callerField())})
-}
+func (sl *spyLogger) Debug(msg string, fields ...log.Field) { sl.record(msg, fields) }
+func (sl *spyLogger) Info(msg string, fields ...log.Field)  { sl.record(msg, fields) }
+func (sl *spyLogger) Warn(msg string, fields ...log.Field)  { sl.record(msg, fields) }
+func (sl *spyLogger) Error(msg string, fields ...log.Field) { sl.record(msg, fields) }
 
-func (sl *spyLogger) Info(msg string, fields ...log.Field) {
-	sl.calls = append(sl.calls, call{msg: msg, fields: append(fields, sl.callerField())})
+func (sl *spyLogger) WithCallerSkip(skip int) log.Logger {
+	return &spyLogger{callerSkip: sl.callerSkip + skip, parent: sl}
 }
 
-func (sl *spyLogger) Error(msg string, fields ...log.Field) {
-	sl.calls = append(sl.calls, call{msg: msg, fields: append(fields, sl.callerField())})
-}
+func (sl *spyLogger) record(msg string, fields []log.Field) {
+	c := call{msg: msg, fields: append(fields, sl.callerField())}
 
-func (sl *spyLogger) AddCallerSkip(skip int) {
-	sl.callerSkip += skip
+	root := sl
+	for root.parent != nil {
+		root = root.parent
+	}
+	root.calls = append(root.calls, c)
 }
 
 func (sl *spyLogger) callerField() log.Field {
-	_, file, line, _ := runtime.Caller(sl.callerSkip + 2)
+	_, file, line, _ := runtime.Caller(sl.callerSkip + 3)
 	file = file[strings.LastIndex(file, "/")+1:]
 	value := fmt.Sprintf("%s:%d", file, line)
 
@@ -0,0 +1,287 @@
+// Package logconformance provides a test suite for Logger implementations.
+// It checks the behaviour every implementation is expected to have,
+// so both the implementations from this module and third-party ones can validate themselves.
+package logconformance
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+)
+
+// Factory creates a new Logger that writes its output to w.
+// The Logger must write entries at all levels, including DEBUG,
+// and, unless SkipCaller is used, annotate them with the caller in "file.go:line" form (the directory may be present).
+// Times must be encoded with the date (e.g. RFC 3339), and durations either as strings (e.g. "1s")
+// or as integers in milliseconds or nanoseconds.
+type Factory func(w io.Writer) log.Logger
+
+// Option configures the test suite.
+type Option func(*config)
+
+type config struct {
+	skipCaller bool
+	unwrap     func(log.Logger) bool
+}
+
+// SkipCaller disables the caller annotation checks, for implementations that don't report callers.
+func SkipCaller() Option { return func(c *config) { c.skipCaller = true } }
+
+// Unwrap enables the checks of the implementation's Unwrap function (e.g. zapimpl.Unwrap),
+// which fn should call, reporting whether the underlying logger has been found.
+func Unwrap(fn func(log.Logger) bool) Option { return func(c *config) { c.unwrap = fn } }
+
+// Run runs the test suite against the Loggers created by the provided factory.
+// Since implementations encode values differently, the output is checked to contain the expected substrings.
+func Run(t *testing.T, factory Factory, opts ...Option) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	t.Run("Levels", func(t *testing.T) { testLevels(t, factory) })
+	t.Run("Fields", func(t *testing.T) { testFields(t, factory) })
+	t.Run("InvalidField", func(t *testing.T) { testInvalidField(t, factory) })
+	if !cfg.skipCaller {
+		t.Run("Caller", func(t *testing.T) { testCaller(t, factory) })
+	}
+	if cfg.unwrap != nil {
+		t.Run("Unwrap", func(t *testing.T) { testUnwrap(t, factory, cfg.unwrap) })
+	}
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, factory) })
+}
+
+func testLevels(t *testing.T, factory Factory) {
+	var w syncWriter
+	logger := factory(&w)
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Error("error message")
+
+	assertContains(t, w.String(), "debug message", "info message", "warn message", "error message")
+}
+
+func testFields(t *testing.T, factory Factory) {
+	tm := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	// the key of each field is checked as well.
+	// Alternative values are separated by "|", since implementations encode values differently.
+	tests := []struct {
+		name   string
+		fields []log.Field
+		want   []string
+	}{
+		{"Int", []log.Field{log.Int("int", 42)}, []string{"42"}},
+		{"Int8", []log.Field{log.Int8("int8", 42)}, []string{"42"}},
+		{"Int16", []log.Field{log.Int16("int16", 42)}, []string{"42"}},
+		{"Int32", []log.Field{log.Int32("int32", 42)}, []string{"42"}},
+		{"Int64", []log.Field{log.Int64("int64", 42)}, []string{"42"}},
+		{"Uint", []log.Field{log.Uint("uint", 42)}, []string{"42"}},
+		{"Uint8", []log.Field{log.Uint8("uint8", 42)}, []string{"42"}},
+		{"Uint16", []log.Field{log.Uint16("uint16", 42)}, []string{"42"}},
+		{"Uint32", []log.Field{log.Uint32("uint32", 42)}, []string{"42"}},
+		{"Uint64", []log.Field{log.Uint64("uint64", 42)}, []string{"42"}},
+		{"Uintptr", []log.Field{log.Uintptr("uintptr", 42)}, []string{"42"}},
+		{"Float32", []log.Field{log.Float32("float32", 1.5)}, []string{"1.5"}},
+		{"Float64", []log.Field{log.Float64("float64", 1.5)}, []string{"1.5"}},
+		{"Complex128", []log.Field{log.Complex128("complex128", 1+2i)}, []string{"1+2i"}},
+		{"Bool", []log.Field{log.Bool("bool", true)}, []string{"true"}},
+		{"String", []log.Field{log.String("string", "value")}, []string{"value"}},
+		{"Time", []log.Field{log.Time("time", tm)}, []string{"2021"}},
+		{"Duration", []log.Field{log.Duration("duration", time.Second)}, []string{"1s|1000"}},
+		{"Error", []log.Field{log.Error(errors.New("some error"))}, []string{"some error"}},
+		{"NamedError", []log.Field{log.NamedError("named_error", errors.New("some error"))}, []string{"some error"}},
+		{"Errors", []log.Field{log.Errors("errors", []error{errors.New("first"), errors.New("second")})}, []string{"first", "second"}},
+		{"NilError", []log.Field{log.Error(nil)}, nil},
+		{"NilNamedError", []log.Field{log.NamedError("nil_error", nil)}, nil},
+		{"NilErrors", []log.Field{log.Errors("nil_errors", []error{nil})}, nil},
+		{"Strings", []log.Field{log.Strings("strings", []string{"first", "second"})}, []string{"first", "second"}},
+		{"Ints", []log.Field{log.Ints("ints", []int{42, 43})}, []string{"42", "43"}},
+		{"ByteString", []log.Field{log.ByteString("bytestring", []byte("value"))}, []string{"value"}},
+		{"Binary", []log.Field{log.Binary("binary", []byte("foo"))}, []string{"Zm9v"}},
+		{"Stringer", []log.Field{log.Stringer("stringer", time.Minute)}, []string{"1m0s"}},
+		{"Durations", []log.Field{log.Durations("durations", []time.Duration{time.Second})}, []string{"1s|1000"}},
+		{"Times", []log.Field{log.Times("times", []time.Time{tm})}, []string{"2021"}},
+		{"Any", []log.Field{log.Any("any", map[string]int{"any_key": 42})}, []string{"any_key", "42"}},
+		{"Object", []log.Field{log.Object(object{})}, []string{"object_key", "value", "deep_key"}},
+		{"Nested", []log.Field{log.Nested("nested", object{})}, []string{"object_key", "value", "deep_key"}},
+		{"Namespace", []log.Field{log.Namespace("namespace"), log.String("namespace_key", "value")}, []string{namespaced("namespace", "namespace_key")}},
+		{"Lazy", []log.Field{log.Lazy("lazy", func() log.Field { return log.String("", "value") })}, []string{"value"}},
+		{"LazyObject", []log.Field{log.LazyObject(func() log.Loggable { return object{} })}, []string{"object_key", "value"}},
+		{"Stack", []log.Field{log.Stack("stack")}, []string{"logconformance"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w syncWriter
+			logger := factory(&w)
+			logger.Info("fields", tt.fields...)
+
+			want := tt.want
+			for _, field := range tt.fields {
+				if field.Key != "" {
+					want = append(want, field.Key)
+				}
+			}
+			assertContains(t, w.String(), want...)
+		})
+	}
+
+	t.Run("NamespaceWithFields", func(t *testing.T) {
+		var w syncWriter
+		logger := log.WithFields(factory(&w), log.Namespace("namespace"), log.String("with_key", "value"))
+		logger.Info("fields", log.String("call_key", "value"))
+
+		// the namespace must cover both the fields added via WithFields and the fields of the call,
+		// so when nested, the call's field follows the last field added via WithFields in the same object.
+		assertContains(t, w.String(), namespaced("namespace", "with_key"), `namespace.call_key|"with_key":"value","call_key"`)
+	})
+}
+
+// namespaced returns the alternatives of the provided key within the namespace:
+// either nested (as encoded by JSON implementations) or dotted (see log.FlattenKeys).
+func namespaced(namespace, key string) string {
+	return namespace + "." + key + "|" + `"` + namespace + `":{"` + key + `"`
+}
+
+func testInvalidField(t *testing.T, factory Factory) {
+	logger := factory(io.Discard)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want a manually created Field to cause panic")
+		}
+	}()
+	logger.Info("invalid", log.Field{Key: "foo"})
+}
+
+func testCaller(t *testing.T, factory Factory) {
+	hook := func(log.Level, string, []log.Field) error { return nil }
+
+	tests := []struct {
+		name string
+		wrap func(log.Logger) log.Logger
+	}{
+		{"Logger", func(l log.Logger) log.Logger { return l }},
+		{"WithFields", func(l log.Logger) log.Logger { return log.WithFields(l, log.Int("foo", 1)) }},
+		{"WithHooks", func(l log.Logger) log.Logger { return log.WithHooks(l, hook) }},
+		{"WithLevel", func(l log.Logger) log.Logger { return log.WithLevel(l, log.DebugLevel) }},
+		{"Named", func(l log.Logger) log.Logger { return log.Named(l, "conformance") }},
+		{"ContextLogger", func(l log.Logger) log.Logger { return log.NewContextLogger(l) }},
+		{"Chain", func(l log.Logger) log.Logger { return log.WithHooks(log.WithFields(l, log.Int("foo", 1)), hook) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w syncWriter
+			logger := tt.wrap(factory(&w))
+
+			_, file, line, _ := runtime.Caller(0)
+			logger.Info("caller") // must be reported as the caller, the line right after runtime.Caller.
+
+			assertContains(t, w.String(), filepath.Base(file)+":"+strconv.Itoa(line+1))
+		})
+	}
+}
+
+func testUnwrap(t *testing.T, factory Factory, unwrap func(log.Logger) bool) {
+	logger := factory(io.Discard)
+
+	tests := map[string]log.Logger{
+		"Logger":        logger,
+		"WithFields":    log.WithFields(logger, log.Int("foo", 1)),
+		"WithHooks":     log.WithHooks(logger),
+		"WithLevel":     log.WithLevel(logger, log.DebugLevel),
+		"Named":         log.Named(logger, "conformance"),
+		"ContextLogger": log.NewContextLogger(logger),
+	}
+	for name, logger := range tests {
+		if !unwrap(logger) {
+			t.Errorf("%s: want the underlying logger to be found", name)
+		}
+	}
+
+	if unwrap(log.Nop) {
+		t.Error("Nop: want the underlying logger not to be found")
+	}
+}
+
+func testConcurrency(t *testing.T, factory Factory) {
+	const goroutines, calls = 8, 16
+
+	var w syncWriter
+	logger := factory(&w)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger := log.WithFields(logger, log.Int("goroutine", i))
+			for j := 0; j < calls; j++ {
+				logger.Info("concurrent", log.Int("call", j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := strings.Count(w.String(), "concurrent"); got != goroutines*calls {
+		t.Errorf("got %d entries; want %d", got, goroutines*calls)
+	}
+}
+
+func assertContains(t *testing.T, output string, want ...string) {
+	t.Helper()
+	for _, s := range want {
+		if !containsAny(output, strings.Split(s, "|")) {
+			t.Errorf("got %q; want the output to contain %q", output, s)
+		}
+	}
+}
+
+func containsAny(output string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(output, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncWriter is a goroutine-safe buffer, since implementations are not required to synchronize writes.
+type syncWriter struct {
+	mu  sync.Mutex // protects buf.
+	buf bytes.Buffer
+}
+
+func (sw *syncWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.buf.Write(p)
+}
+
+func (sw *syncWriter) String() string {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.buf.String()
+}
+
+type object struct{}
+
+func (object) ToLog() []log.Field {
+	return []log.Field{log.String("object_key", "value"), log.Object(deepObject{})}
+}
+
+type deepObject struct{}
+
+func (deepObject) ToLog() []log.Field { return []log.Field{log.Int("deep_key", 42)} }
@@ -0,0 +1,173 @@
+// Package logfmtimpl contains a dependency-free logfmt implementation of Logger interface.
+package logfmtimpl
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/junk1tm/log"
+)
+
+// Option configures the Logger created by NewLogger.
+type Option func(*logger)
+
+// TimeKey sets the key used for the entry's time (default: "time").
+// An empty key disables the time annotation.
+func TimeKey(key string) Option { return func(l *logger) { l.timeKey = key } }
+
+// TimeFormat sets the layout used for the entry's time and Time fields (default: time.RFC3339Nano).
+func TimeFormat(layout string) Option { return func(l *logger) { l.timeFormat = layout } }
+
+// LevelKey sets the key used for the entry's level (default: "level").
+func LevelKey(key string) Option { return func(l *logger) { l.levelKey = key } }
+
+// MessageKey sets the key used for the entry's message (default: "msg").
+func MessageKey(key string) Option { return func(l *logger) { l.messageKey = key } }
+
+// NewLogger creates a new log.Logger that writes each entry to w as a single line of logfmt.
+// Values containing spaces, quotes, equals signs or non-printable characters are quoted and escaped,
+// invalid characters in keys are replaced with underscores.
+// It is safe for concurrent use as long as w is not used by anyone else.
+func NewLogger(w io.Writer, opts ...Option) log.Logger {
+	l := &logger{
+		w:          w,
+		timeKey:    "time",
+		timeFormat: time.RFC3339Nano,
+		levelKey:   "level",
+		messageKey: "msg",
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+type logger struct {
+	mu         sync.Mutex // protects w.
+	w          io.Writer
+	timeKey    string
+	timeFormat string
+	levelKey   string
+	messageKey string
+}
+
+func (l *logger) Debug(msg string, fields ...log.Field) { l.log(log.DebugLevel, msg, fields) }
+func (l *logger) Info(msg string, fields ...log.Field)  { l.log(log.InfoLevel, msg, fields) }
+func (l *logger) Warn(msg string, fields ...log.Field)  { l.log(log.WarnLevel, msg, fields) }
+func (l *logger) Error(msg string, fields ...log.Field) { l.log(log.ErrorLevel, msg, fields) }
+
+var bufPool = sync.Pool{
+	New: func() interface{} { return new([]byte) },
+}
+
+func (l *logger) log(lvl log.Level, msg string, fields []log.Field) {
+	now := time.Now()
+
+	bufp := bufPool.Get().(*[]byte)
+	defer bufPool.Put(bufp)
+
+	buf := (*bufp)[:0]
+	if l.timeKey != "" {
+		buf = appendKey(buf, l.timeKey)
+		buf = appendString(buf, now.Format(l.timeFormat))
+	}
+	buf = appendKey(buf, l.levelKey)
+	buf = appendString(buf, lvl.String())
+	buf = appendKey(buf, l.messageKey)
+	buf = appendString(buf, msg)
+
+	for _, field := range log.FlattenKeys(fields) {
+		buf = appendKey(buf, field.Key)
+		buf = l.appendValue(buf, field)
+	}
+
+	buf = append(buf, '\n')
+	*bufp = buf
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(buf)
+}
+
+func (l *logger) appendValue(buf []byte, field log.Field) []byte {
+	return appendString(buf, field.ValueString(l.timeFormat))
+}
+
+// appendKey appends the provided key preceded by a space if needed.
+// Since logfmt keys cannot be quoted, invalid characters are replaced with underscores.
+func appendKey(buf []byte, key string) []byte {
+	if len(buf) > 0 {
+		buf = append(buf, ' ')
+	}
+	if key == "" {
+		buf = append(buf, '_')
+	}
+	for i, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !strconv.IsPrint(r) {
+			buf = append(buf, '_')
+		} else {
+			buf = append(buf, key[i:i+utf8.RuneLen(r)]...)
+		}
+	}
+	return append(buf, '=')
+}
+
+// appendString appends the provided string, quoting it if needed.
+func appendString(buf []byte, s string) []byte {
+	if !needsQuoting(s) {
+		return append(buf, s...)
+	}
+
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		switch {
+		case r == '"' || r == '\\':
+			buf = append(buf, '\\', byte(r))
+		case r == '\n':
+			buf = append(buf, '\\', 'n')
+		case r == '\r':
+			buf = append(buf, '\\', 'r')
+		case r == '\t':
+			buf = append(buf, '\\', 't')
+		case r == utf8.RuneError && size == 1:
+			buf = append(buf, "\\ufffd"...)
+		case r < ' ' || !strconv.IsPrint(r):
+			buf = appendEscapedRune(buf, r)
+		default:
+			buf = append(buf, s[i-size:i]...)
+		}
+	}
+	return append(buf, '"')
+}
+
+const hex = "0123456789abcdef"
+
+// appendEscapedRune appends the provided rune as a \uXXXX or \UXXXXXXXX escape sequence.
+func appendEscapedRune(buf []byte, r rune) []byte {
+	if r < 0x10000 {
+		return append(buf, '\\', 'u', hex[r>>12&0xf], hex[r>>8&0xf], hex[r>>4&0xf], hex[r&0xf])
+	}
+	buf = append(buf, '\\', 'U')
+	for shift := 28; shift >= 0; shift -= 4 {
+		buf = append(buf, hex[r>>uint(shift)&0xf])
+	}
+	return buf
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
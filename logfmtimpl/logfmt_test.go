@@ -0,0 +1,82 @@
+package logfmtimpl_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logconformance"
+	"github.com/junk1tm/log/logfmtimpl"
+)
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		name  string
+		field log.Field
+		want  string
+	}{
+		{"int", log.Int("int", -1), "int=-1"},
+		{"uint64", log.Uint64("uint64", 64), "uint64=64"},
+		{"float64", log.Float64("float64", 1.5), "float64=1.5"},
+		{"bool", log.Bool("bool", true), "bool=true"},
+		{"plain string", log.String("string", "foo"), "string=foo"},
+		{"empty string", log.String("string", ""), `string=""`},
+		{"string with space", log.String("string", "foo bar"), `string="foo bar"`},
+		{"string with equals sign", log.String("string", "a=b"), `string="a=b"`},
+		{"string with quotes", log.String("string", `"foo"`), `string="\"foo\""`},
+		{"string with backslash", log.String("string", `C:\foo`), `string="C:\\foo"`},
+		{"string with newline", log.String("string", "foo\nbar\r\t"), `string="foo\nbar\r\t"`},
+		{"string with control char", log.String("string", "\x00"), `string="\u0000"`},
+		{"string with invalid utf8", log.String("string", "\xff"), `string="\ufffd"`},
+		{"unicode string", log.String("string", "日本語"), "string=日本語"},
+		{"invalid key", log.String("a b=\"c\"", "foo"), "a_b__c_=foo"},
+		{"empty key", log.String("", "foo"), "_=foo"},
+		{"time", log.Time("time", time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)), "time=2021-11-01T12:00:00Z"},
+		{"duration", log.Duration("duration", 1500*time.Millisecond), "duration=1.5s"},
+		{"error", log.Error(errors.New("some error")), `error="some error"`},
+		{"named error", log.NamedError("err", errors.New("some error")), `err="some error"`},
+		{"nil error", log.Error(nil), "error=<nil>"},
+		{"errors", log.Errors("errors", []error{errors.New("a"), errors.New("b")}), `errors="[a b]"`},
+		{"errors with nil", log.Errors("errors", []error{errors.New("a"), nil}), `errors="[a <nil>]"`},
+		{"object", log.Object(object{}), "nested=value"},
+		{"nested", log.Nested("obj", object{}), "obj.nested=value"},
+		{"strings", log.Strings("strings", []string{"a", "b"}), `strings="[a b]"`},
+		{"ints", log.Ints("ints", []int{1, 2}), `ints="[1 2]"`},
+		{"bytestring", log.ByteString("bytestring", []byte("foo")), "bytestring=foo"},
+		{"binary", log.Binary("binary", []byte("foo")), "binary=Zm9v"},
+		{"stringer", log.Stringer("stringer", time.Minute), "stringer=1m0s"},
+		{"uintptr", log.Uintptr("uintptr", 1), "uintptr=1"},
+		{"complex128", log.Complex128("complex128", 1+2i), "complex128=(1+2i)"},
+		{"durations", log.Durations("durations", []time.Duration{time.Second}), "durations=[1s]"},
+		{"any", log.Any("any", struct{ A int }{1}), "any={A:1}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logfmtimpl.NewLogger(&buf, logfmtimpl.TimeKey(""), logfmtimpl.LevelKey("lvl"), logfmtimpl.MessageKey("m"))
+			logger.Info("test", tt.field)
+
+			want := "lvl=info m=test " + tt.want + "\n"
+			if got := buf.String(); got != want {
+				t.Errorf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+type object struct{}
+
+func (object) ToLog() []log.Field {
+	return []log.Field{log.String("nested", "value")}
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		return logfmtimpl.NewLogger(w)
+	}, logconformance.SkipCaller())
+}
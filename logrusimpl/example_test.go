@@ -19,12 +19,14 @@ func ExampleNewLogger() {
 	logger := logrusimpl.NewLogger(ll)
 	logger.Debug("example 1", log.Int("foo", 1))
 	logger.Info("example 2", log.Int("bar", 2))
-	logger.Error("example 3", log.Int("baz", 3))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
 
 	// output:
 	// {"foo":1,"level":"debug","msg":"example 1"}
 	// {"bar":2,"level":"info","msg":"example 2"}
-	// {"baz":3,"level":"error","msg":"example 3"}
+	// {"baz":3,"level":"warning","msg":"example 3"}
+	// {"level":"error","msg":"example 4","qux":4}
 }
 
 func ExampleUnwrap() {
@@ -8,3 +8,7 @@ require (
 )
 
 require golang.org/x/sys v0.0.0-20191026070338-33540a1f6037 // indirect
+
+// The replace makes the module build against the log package from this repository, since it uses API not yet released.
+// Release step: tag the log module first, then bump the require above to that version, so consumers (who ignore replace) get the API too.
+replace github.com/junk1tm/log => ../
@@ -2,43 +2,180 @@
 package logrusimpl
 
 import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
 	"github.com/junk1tm/log"
 	"github.com/sirupsen/logrus"
 )
 
 // NewLogger creates a new log.Logger from the provided logrus.Logger.
+// If logrus.Logger.ReportCaller is enabled, add CallerHook to the logger to report correct callers:
+//
+//	ll.AddHook(logrusimpl.CallerHook())
 func NewLogger(logger *logrus.Logger) log.Logger {
-	return &wrapper{
-		logger: logger,
-	}
+	return &wrapper{logger: logger}
 }
 
 type wrapper struct {
-	logger *logrus.Logger
+	logger     *logrus.Logger
+	callerSkip int
 }
 
-func (w *wrapper) Debug(msg string, fields ...log.Field) {
-	w.logger.WithFields(logrusFields(fields)).Debug(msg)
+func (w *wrapper) Debug(msg string, fields ...log.Field) { w.log(logrus.DebugLevel, msg, fields) }
+func (w *wrapper) Info(msg string, fields ...log.Field)  { w.log(logrus.InfoLevel, msg, fields) }
+func (w *wrapper) Warn(msg string, fields ...log.Field)  { w.log(logrus.WarnLevel, msg, fields) }
+func (w *wrapper) Error(msg string, fields ...log.Field) { w.log(logrus.ErrorLevel, msg, fields) }
+
+func (w *wrapper) Enabled(lvl log.Level) bool { return w.logger.IsLevelEnabled(logrusLevel(lvl)) }
+
+func (w *wrapper) WithCallerSkip(skip int) log.Logger {
+	return &wrapper{
+		logger:     w.logger,
+		callerSkip: w.callerSkip + skip,
+	}
 }
 
-func (w *wrapper) Info(msg string, fields ...log.Field) {
-	w.logger.WithFields(logrusFields(fields)).Info(msg)
+// log checks the level before converting the fields, so Lazy fields of dropped entries are never evaluated.
+func (w *wrapper) log(lvl logrus.Level, msg string, fields []log.Field) {
+	if !w.logger.IsLevelEnabled(lvl) {
+		return
+	}
+
+	entry := &logrus.Entry{
+		Logger: w.logger,
+		Data:   logrusFields(fields),
+	}
+	logSkip(w.callerSkip, entry, lvl, msg)
 }
 
-func (w *wrapper) Error(msg string, fields ...log.Field) {
-	w.logger.WithFields(logrusFields(fields)).Error(msg)
+// logSkip logs the entry through skip nested calls of itself, which callerHook counts
+// to find out the number of callers to skip, leaving the entry's context to the user.
+//
+//go:noinline
+func logSkip(skip int, entry *logrus.Entry, lvl logrus.Level, msg string) {
+	if skip > 0 {
+		logSkip(skip-1, entry, lvl, msg)
+		return
+	}
+	entry.Log(lvl, msg)
+}
+
+func logrusLevel(lvl log.Level) logrus.Level {
+	switch lvl {
+	case log.DebugLevel:
+		return logrus.DebugLevel
+	case log.InfoLevel:
+		return logrus.InfoLevel
+	case log.WarnLevel:
+		return logrus.WarnLevel
+	case log.ErrorLevel:
+		return logrus.ErrorLevel
+	default:
+		panic(fmt.Sprintf("unexpected level %v", lvl))
+	}
 }
 
 func logrusFields(fields []log.Field) map[string]interface{} {
 	lf := make(map[string]interface{}, len(fields))
 
-	for _, field := range log.FlattenFields(fields) {
-		lf[field.Key] = field.Value
+	for _, field := range log.FlattenKeys(fields) {
+		switch field.Kind {
+		case log.ByteStringKind, log.BinaryKind, log.StringerKind:
+			// a nil fmt.Stringer is encoded as "<nil>" instead of panicking.
+			lf[field.Key] = field.ValueString(time.RFC3339Nano)
+		case log.ErrorsKind:
+			// errors are encoded by logrus.JSONFormatter as empty objects, unless they are top-level values.
+			errs := field.Interface.([]error)
+			messages := make([]string, len(errs))
+			for i, err := range errs {
+				messages[i] = fmt.Sprint(err)
+			}
+			lf[field.Key] = messages
+		case log.Complex128Kind:
+			// complex numbers are not supported by logrus.JSONFormatter.
+			lf[field.Key] = field.ValueString(time.RFC3339Nano)
+		default:
+			lf[field.Key] = field.Value()
+		}
 	}
 
 	return lf
 }
 
+// CallerHook returns a logrus.Hook that fixes the caller of entries logged via NewLogger.
+// By default, logrus reports the first caller outside of its package, which is the log.Logger wrapper.
+// Instead, the hook reports the caller of the log.Logger, taking log.WithCallerSkip into account.
+// Entries logged via logrus directly are left as is.
+func CallerHook() logrus.Hook { return callerHook{} }
+
+// callerHook reports the first caller outside of both logrus and this package,
+// additionally skipping the number of callers recorded by logSkip.
+type callerHook struct{}
+
+func (callerHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (callerHook) Fire(entry *logrus.Entry) error {
+	// the caller is set only if logrus.Logger.ReportCaller is enabled.
+	if entry.Caller == nil {
+		return nil
+	}
+
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	skip := -1 // the outermost logSkip call is not a skipped caller.
+	inLogger := true
+	for {
+		frame, more := frames.Next()
+		if pkg := packageName(frame.Function); inLogger && (pkg == logrusPackage || pkg == thisPackage) {
+			if frame.Function == logSkipFunction {
+				skip++
+			}
+			if !more {
+				return nil
+			}
+			continue
+		}
+
+		if skip < 0 {
+			// not logged via NewLogger.
+			return nil
+		}
+
+		inLogger = false
+		if skip == 0 {
+			entry.Caller = &frame
+			return nil
+		}
+		skip--
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+var (
+	logrusPackage   = packageName(runtime.FuncForPC(reflect.ValueOf(logrus.New).Pointer()).Name())
+	logSkipFunction = runtime.FuncForPC(reflect.ValueOf(logSkip).Pointer()).Name()
+	thisPackage     = packageName(logSkipFunction)
+)
+
+// packageName returns the package name of the provided function,
+// e.g. "github.com/sirupsen/logrus" for "github.com/sirupsen/logrus.(*Entry).log".
+func packageName(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
 // Unwrap unwraps the provided logger,
 // allowing access to the underlying logrus.Logger.
 // It returns true on success, false otherwise.
@@ -0,0 +1,164 @@
+package logrusimpl_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/junk1tm/log"
+	"github.com/sirupsen/logrus"
+
+	"github.com/junk1tm/log/logconformance"
+	"github.com/junk1tm/log/logrusimpl"
+)
+
+func TestCaller(t *testing.T) {
+	var buf bytes.Buffer
+	ll := logrus.New()
+	ll.Out = &buf
+	ll.ReportCaller = true
+	ll.AddHook(logrusimpl.CallerHook())
+	ll.Formatter = &logrus.JSONFormatter{
+		DisableTimestamp: true,
+		CallerPrettyfier: func(f *runtime.Frame) (function string, file string) {
+			return "", fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
+		},
+	}
+
+	hook := func(lvl log.Level, msg string, fields []log.Field) error { return nil }
+
+	logger := logrusimpl.NewLogger(ll)
+	logger.Info("first call")
+	logger = log.WithFields(logger, log.Int("foo", 1))
+	logger.Info("second call")
+	logger = log.WithHooks(logger, hook)
+	logger.Info("third call")
+	logger = log.WithFields(logrusimpl.NewLogger(ll), log.Int("bar", 2))
+	logger.Info("fourth call")
+
+	want := []string{
+		`{"file":"logrus_test.go:37","level":"info","msg":"first call"}`,
+		`{"file":"logrus_test.go:39","foo":1,"level":"info","msg":"second call"}`,
+		`{"file":"logrus_test.go:41","foo":1,"level":"info","msg":"third call"}`,
+		`{"bar":2,"file":"logrus_test.go:43","level":"info","msg":"fourth call"}`,
+	}
+	if got := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var buf bytes.Buffer
+	ll := logrus.New()
+	ll.Out = &buf
+	ll.Level = logrus.InfoLevel
+	ll.Formatter = &logrus.JSONFormatter{DisableTimestamp: true}
+	logger := logrusimpl.NewLogger(ll)
+
+	var evaluated int
+	lazy := log.Lazy("lazy", func() log.Field { evaluated++; return log.Int("", evaluated) })
+	logger.Debug("dropped", lazy)
+	logger.Info("written", lazy)
+
+	want := `{"lazy":1,"level":"info","msg":"written"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	if enabler := logger.(log.LevelEnabler); enabler.Enabled(log.DebugLevel) || !enabler.Enabled(log.InfoLevel) {
+		t.Error("want DEBUG to be disabled and INFO to be enabled")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var buf bytes.Buffer
+	ll := logrus.New()
+	ll.Out = &buf
+	ll.Formatter = &logrus.JSONFormatter{DisableTimestamp: true}
+	logger := logrusimpl.NewLogger(ll)
+
+	logger.Error("cleanup failed",
+		log.Error(errors.New("primary")),
+		log.NamedError("cleanup_error", errors.New("cleanup")),
+		log.Errors("errors", []error{errors.New("a"), errors.New("b")}),
+	)
+
+	want := `{"cleanup_error":"cleanup","error":"primary","errors":["a","b"],"level":"error","msg":"cleanup failed"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		ll := logrus.New()
+		ll.Out = w
+		ll.Level = logrus.DebugLevel
+		ll.ReportCaller = true
+		ll.AddHook(logrusimpl.CallerHook())
+		ll.Formatter = &logrus.JSONFormatter{}
+		return logrusimpl.NewLogger(ll)
+	}, logconformance.Unwrap(func(logger log.Logger) bool {
+		_, ok := logrusimpl.Unwrap(logger)
+		return ok
+	}))
+}
+
+func TestNewLogger_notModified(t *testing.T) {
+	ll := logrus.New()
+	logrusimpl.NewLogger(ll)
+
+	if got := len(ll.Hooks[logrus.InfoLevel]); got != 0 {
+		t.Errorf("got %d hooks; want 0", got)
+	}
+}
+
+func TestCallerHook_context(t *testing.T) {
+	type traceKey struct{}
+
+	var got interface{}
+	ll := logrus.New()
+	ll.Out = io.Discard
+	ll.ReportCaller = true
+	ll.AddHook(logrusimpl.CallerHook())
+	ll.AddHook(contextHook(func(entry *logrus.Entry) {
+		if entry.Context != nil {
+			got = entry.Context.Value(traceKey{})
+		}
+	}))
+
+	// the hook must not take over the entry's context used by other hooks.
+	log.WithFields(logrusimpl.NewLogger(ll), log.Int("foo", 1)).Info("via wrapper")
+	if got != nil {
+		t.Errorf("got %v; want nil", got)
+	}
+	ll.WithContext(context.WithValue(context.Background(), traceKey{}, "trace")).Info("via logrus")
+	if got != "trace" {
+		t.Errorf("got %v; want trace", got)
+	}
+}
+
+type contextHook func(entry *logrus.Entry)
+
+func (contextHook) Levels() []logrus.Level       { return logrus.AllLevels }
+func (h contextHook) Fire(e *logrus.Entry) error { h(e); return nil }
+
+func TestStringer_nil(t *testing.T) {
+	var buf bytes.Buffer
+	ll := logrus.New()
+	ll.Out = &buf
+	ll.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+	logger := logrusimpl.NewLogger(ll)
+
+	logger.Info("nil stringer", log.Stringer("stringer", nil))
+
+	want := `level=info msg="nil stringer" stringer="<nil>"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
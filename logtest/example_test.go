@@ -0,0 +1,24 @@
+package logtest_test
+
+import (
+	"fmt"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logtest"
+)
+
+func ExampleNew() {
+	logger, logs := logtest.New()
+	logger.Debug("example 1", log.Int("foo", 1))
+	logger.Info("example 2", log.Int("bar", 2))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
+
+	for _, entry := range logs.FilterLevel(log.WarnLevel).All() {
+		fmt.Println(entry)
+	}
+
+	// output:
+	// WARN example 3 baz=3
+}
@@ -0,0 +1,78 @@
+package logtest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/junk1tm/log"
+)
+
+// The flag is registered when the package is imported, so it is namespaced
+// to avoid conflicts with the -update flags commonly defined by test packages.
+var update = flag.Bool("logtest.update", false, "update the golden files used by logtest.Golden")
+
+// Golden creates a new log.Logger that records all logging operations during the test
+// and, when the test completes, compares them against the testdata/<name>.golden file.
+// Each entry is rendered as a line in the form returned by Entry.String,
+// with volatile values (times, durations and stack traces) replaced by placeholders, e.g. "<time>".
+// Callers are omitted. Run the test with the -logtest.update flag to create or update the golden file.
+func Golden(t testing.TB, name string) log.Logger {
+	logger, logs := New()
+	path := filepath.Join("testdata", name+".golden")
+
+	t.Cleanup(func() {
+		got := renderGolden(logs.All())
+
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Errorf("could not create golden file directory: %v", err)
+				return
+			}
+			if err := os.WriteFile(path, got, 0o644); err != nil {
+				t.Errorf("could not update golden file: %v", err)
+			}
+			return
+		}
+
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("could not read golden file (run with -logtest.update to create it): %v", err)
+			return
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("log output does not match %s (run with -logtest.update to accept it):\ngot:\n%s\nwant:\n%s", path, got, want)
+		}
+	})
+
+	return logger
+}
+
+func renderGolden(entries []Entry) []byte {
+	var sb strings.Builder
+	for _, entry := range entries {
+		sb.WriteString(strings.ToUpper(entry.Level.String()))
+		sb.WriteByte(' ')
+		sb.WriteString(entry.Message)
+		writeFields(&sb, entry.Fields, goldenValue)
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+// goldenValue is like formatValue, but replaces volatile values with placeholders.
+func goldenValue(field log.Field) string {
+	switch field.Kind {
+	case log.TimeKind, log.TimesKind:
+		return "<time>"
+	case log.DurationKind, log.DurationsKind:
+		return "<duration>"
+	case log.StackKind:
+		return "<stack>"
+	default:
+		return formatValue(field)
+	}
+}
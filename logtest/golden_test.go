@@ -0,0 +1,50 @@
+package logtest_test
+
+import (
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logtest"
+)
+
+// test packages importing logtest must be able to define their own -update flag.
+var _ = flag.Bool("update", false, "update the golden files of this package")
+
+func TestGolden(t *testing.T) {
+	logger := logtest.Golden(t, "golden")
+	logger = log.WithFields(logger, log.Nested("request", request{id: 1, start: time.Now()}))
+	logger.Info("request started", log.String("path", "/foo"))
+	logger.Error("request failed",
+		log.Duration("elapsed", time.Since(time.Now())),
+		log.Error(errors.New("some error")),
+		log.Stack("stack"),
+	)
+}
+
+func TestGolden_mismatch(t *testing.T) {
+	if flag.Lookup("logtest.update").Value.String() == "true" {
+		t.Skip("the golden file must not be updated with a mismatching output")
+	}
+
+	var rt recordingT
+	logger := logtest.Golden(&rt, "golden")
+	logger.Info("unexpected call")
+	rt.cleanup()
+
+	if !rt.failed {
+		t.Error("want the test to fail")
+	}
+}
+
+type request struct {
+	id    int
+	start time.Time
+}
+
+func (r request) ToLog() []log.Field {
+	return []log.Field{log.Int("id", r.id), log.Time("start", r.start)}
+}
@@ -0,0 +1,205 @@
+// Package logtest provides Logger implementations useful in tests.
+package logtest
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/junk1tm/log"
+)
+
+// Entry is a logging operation recorded by the Logger created by New.
+type Entry struct {
+	Level   log.Level
+	Message string
+	Fields  []log.Field // flattened, see log.FlattenFields.
+	Caller  string      // in "file.go:line" form, the file's directory is omitted.
+}
+
+// String returns the entry in a compact human-readable form: level, message and fields in "key=value" form.
+// The caller is omitted.
+func (e Entry) String() string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToUpper(e.Level.String()))
+	sb.WriteByte(' ')
+	sb.WriteString(e.Message)
+	writeFields(&sb, e.Fields, formatValue)
+	return sb.String()
+}
+
+// writeFields writes the provided flattened fields, using dotted keys for Nested and Namespace fields.
+func writeFields(sb *strings.Builder, fields []log.Field, format func(log.Field) string) {
+	for _, field := range log.DottedKeys(fields) {
+		sb.WriteByte(' ')
+		sb.WriteString(field.Key)
+		sb.WriteByte('=')
+		sb.WriteString(format(field))
+	}
+}
+
+// formatValue formats the value of the provided field the same way as the other text implementations
+// (see log.Field.ValueString), quoting it if needed.
+func formatValue(field log.Field) string {
+	return quote(field.ValueString(time.RFC3339Nano))
+}
+
+// quote quotes the provided value if it is empty or contains spaces, quotes, equals signs or non-printable characters.
+func quote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || !strconv.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
+
+// New creates a new log.Logger that records all logging operations in memory,
+// and the Logs to inspect them. It is safe for concurrent use.
+// The Logger reports correct callers when used as the inner Logger of log.WithFields, log.WithHooks, etc.
+func New() (log.Logger, *Logs) {
+	logs := new(Logs)
+	return &logger{logs: logs}, logs
+}
+
+type logger struct {
+	logs       *Logs
+	callerSkip int
+}
+
+func (l *logger) Debug(msg string, fields ...log.Field) { l.log(log.DebugLevel, msg, fields) }
+func (l *logger) Info(msg string, fields ...log.Field)  { l.log(log.InfoLevel, msg, fields) }
+func (l *logger) Warn(msg string, fields ...log.Field)  { l.log(log.WarnLevel, msg, fields) }
+func (l *logger) Error(msg string, fields ...log.Field) { l.log(log.ErrorLevel, msg, fields) }
+
+func (l *logger) WithCallerSkip(skip int) log.Logger {
+	return &logger{logs: l.logs, callerSkip: l.callerSkip + skip}
+}
+
+func (l *logger) log(lvl log.Level, msg string, fields []log.Field) {
+	entry := Entry{
+		Level:   lvl,
+		Message: msg,
+		Fields:  log.FlattenFields(fields),
+	}
+	if _, file, line, ok := runtime.Caller(l.callerSkip + 2); ok {
+		entry.Caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	l.logs.add(entry)
+}
+
+// Logs is a goroutine-safe collection of recorded entries.
+type Logs struct {
+	mu      sync.Mutex // protects entries.
+	entries []Entry
+}
+
+func (l *Logs) add(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// All returns a copy of all the recorded entries.
+func (l *Logs) All() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]Entry, len(l.entries))
+	copy(entries, l.entries)
+	return entries
+}
+
+// Len returns the number of the recorded entries.
+func (l *Logs) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.entries)
+}
+
+// TakeAll returns all the recorded entries and removes them from the collection.
+func (l *Logs) TakeAll() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.entries
+	l.entries = nil
+	return entries
+}
+
+// FilterLevel returns a new collection of the entries logged at the provided level.
+func (l *Logs) FilterLevel(lvl log.Level) *Logs {
+	return l.filter(func(e Entry) bool { return e.Level == lvl })
+}
+
+// FilterMessage returns a new collection of the entries with the provided message.
+func (l *Logs) FilterMessage(msg string) *Logs {
+	return l.filter(func(e Entry) bool { return e.Message == msg })
+}
+
+// FilterField returns a new collection of the entries containing the provided field.
+func (l *Logs) FilterField(field log.Field) *Logs {
+	return l.filter(func(e Entry) bool { return containsField(e.Fields, field) })
+}
+
+func (l *Logs) filter(keep func(Entry) bool) *Logs {
+	filtered := new(Logs)
+	for _, entry := range l.All() {
+		if keep(entry) {
+			filtered.entries = append(filtered.entries, entry)
+		}
+	}
+	return filtered
+}
+
+// AssertLogged reports an error to t unless an entry matching the provided one has been recorded.
+// An entry matches if it has the same level and message, contains all the provided fields (in any order)
+// and, unless the provided caller is empty, has the same caller.
+func (l *Logs) AssertLogged(t testing.TB, want Entry) {
+	t.Helper()
+
+	entries := l.All()
+	for _, entry := range entries {
+		if matches(entry, want) {
+			return
+		}
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		sb.WriteString("\n\t")
+		sb.WriteString(entry.String())
+		sb.WriteString(" (" + entry.Caller + ")")
+	}
+	t.Errorf("no entry matching %q (%s) has been logged; got %d entries:%s", want, want.Caller, len(entries), sb.String())
+}
+
+func matches(entry, want Entry) bool {
+	if entry.Level != want.Level || entry.Message != want.Message {
+		return false
+	}
+	if want.Caller != "" && entry.Caller != want.Caller {
+		return false
+	}
+	for _, field := range want.Fields {
+		if !containsField(entry.Fields, field) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsField(fields []log.Field, field log.Field) bool {
+	for _, f := range fields {
+		if reflect.DeepEqual(f, field) {
+			return true
+		}
+	}
+	return false
+}
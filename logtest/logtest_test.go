@@ -0,0 +1,160 @@
+package logtest_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logtest"
+)
+
+func TestLogger(t *testing.T) {
+	logger, logs := logtest.New()
+	logger = log.WithFields(logger, log.Int("foo", 1))
+	logger.Info("first call")
+	logger = log.WithHooks(logger, func(log.Level, string, []log.Field) error { return nil })
+	logger.Error("second call", log.Error(errors.New("some error")))
+
+	want := []logtest.Entry{
+		{
+			Level:   log.InfoLevel,
+			Message: "first call",
+			Fields:  []log.Field{log.Int("foo", 1)},
+			Caller:  "logtest_test.go:17",
+		},
+		{
+			Level:   log.ErrorLevel,
+			Message: "second call",
+			Fields:  []log.Field{log.Int("foo", 1), log.Error(errors.New("some error"))},
+			Caller:  "logtest_test.go:19",
+		},
+	}
+	if got := logs.All(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+
+	logs.AssertLogged(t, logtest.Entry{Level: log.ErrorLevel, Message: "second call", Caller: "logtest_test.go:19"})
+	logs.AssertLogged(t, logtest.Entry{Level: log.InfoLevel, Message: "first call", Fields: []log.Field{log.Int("foo", 1)}})
+
+	if got := logs.TakeAll(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+	if got := logs.Len(); got != 0 {
+		t.Errorf("got %d; want no entries after TakeAll", got)
+	}
+}
+
+func TestLogs_Filter(t *testing.T) {
+	logger, logs := logtest.New()
+	logger.Debug("foo", log.Int("i", 1))
+	logger.Info("foo", log.Int("i", 2))
+	logger.Info("bar", log.Int("i", 1))
+
+	tests := map[string]struct {
+		logs *logtest.Logs
+		want []string
+	}{
+		"level":   {logs.FilterLevel(log.InfoLevel), []string{"INFO foo i=2", "INFO bar i=1"}},
+		"message": {logs.FilterMessage("foo"), []string{"DEBUG foo i=1", "INFO foo i=2"}},
+		"field":   {logs.FilterField(log.Int("i", 1)), []string{"DEBUG foo i=1", "INFO bar i=1"}},
+		"chained": {logs.FilterLevel(log.InfoLevel).FilterMessage("foo"), []string{"INFO foo i=2"}},
+	}
+
+	for name, tt := range tests {
+		var got []string
+		for _, entry := range tt.logs.All() {
+			got = append(got, entry.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q; want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestLogs_AssertLogged(t *testing.T) {
+	logger, logs := logtest.New()
+	logger.Info("foo", log.Int("i", 1))
+
+	for _, want := range []logtest.Entry{
+		{Level: log.WarnLevel, Message: "foo"},
+		{Level: log.InfoLevel, Message: "bar"},
+		{Level: log.InfoLevel, Message: "foo", Fields: []log.Field{log.Int("i", 2)}},
+		{Level: log.InfoLevel, Message: "foo", Caller: "foo.go:1"},
+	} {
+		var ft fakeT
+		logs.AssertLogged(&ft, want)
+		if !ft.failed {
+			t.Errorf("%v: want AssertLogged to fail", want)
+		}
+	}
+}
+
+func TestLogger_concurrency(t *testing.T) {
+	logger, logs := logtest.New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			log.WithFields(logger, log.Int("i", i)).Info("concurrent call")
+			_ = logs.FilterField(log.Int("i", i)).Len()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := logs.Len(); got != 10 {
+		t.Errorf("got %d; want 10 entries", got)
+	}
+}
+
+func TestEntry_String(t *testing.T) {
+	logger, logs := logtest.New()
+	logger.Error("failed",
+		log.Error(wrappedError{errors.New("base")}),
+		log.Nested("user", user{id: 1}),
+		log.Namespace("request"),
+		log.String("method", "GET"),
+	)
+
+	want := `ERROR failed error="op: base" error_chain="[op: base base]" user.id=1 request.method=GET`
+	if got := logs.All()[0].String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestEntry_String_values(t *testing.T) {
+	logger, logs := logtest.New()
+	logger.Info("values",
+		log.Binary("binary", []byte("foo")),
+		log.ByteString("bytestring", []byte("foo bar")),
+		log.NamedError("nil_error", nil),
+		log.Stringer("nil_stringer", nil),
+	)
+
+	want := `INFO values binary=Zm9v bytestring="foo bar" nil_error=<nil> nil_stringer=<nil>`
+	if got := logs.All()[0].String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+type wrappedError struct{ err error }
+
+func (e wrappedError) Error() string { return "op: " + e.err.Error() }
+func (e wrappedError) Unwrap() error { return e.err }
+
+type user struct{ id int }
+
+func (u user) ToLog() []log.Field { return []log.Field{log.Int("id", u.id)} }
+
+// fakeT records whether the test has failed.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (ft *fakeT) Helper()                                   {}
+func (ft *fakeT) Errorf(format string, args ...interface{}) { ft.failed = true }
@@ -0,0 +1,100 @@
+package logtest
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/junk1tm/log"
+)
+
+// Option configures the Logger created by NewT.
+type Option func(*tState)
+
+// FailOnError sets whether entries logged at ERROR level fail the test (default: false).
+func FailOnError(enabled bool) Option { return func(s *tState) { s.failOnError = enabled } }
+
+// NewT creates a new log.Logger that writes each entry to t.Log in the form returned by Entry.String,
+// so the output is shown per test and only for failed tests (or with the -v flag).
+// Entries logged after the test has completed are dropped.
+// Since t.Log reports the line of the Logger's direct caller,
+// entries logged through wrappers (e.g. log.WithFields) are additionally annotated with the actual caller.
+func NewT(t testing.TB, opts ...Option) log.Logger {
+	s := &tState{t: t}
+	for _, opt := range opts {
+		opt(s)
+	}
+	t.Cleanup(s.stop)
+	return &tLogger{state: s}
+}
+
+// tState is shared by the Logger created by NewT and its children.
+type tState struct {
+	t           testing.TB
+	failOnError bool
+
+	mu   sync.Mutex // protects done, held while writing to t to not race with the test completion.
+	done bool
+}
+
+func (s *tState) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+}
+
+type tLogger struct {
+	state      *tState
+	callerSkip int
+}
+
+func (l *tLogger) Debug(msg string, fields ...log.Field) {
+	l.state.t.Helper()
+	l.log(log.DebugLevel, msg, fields)
+}
+
+func (l *tLogger) Info(msg string, fields ...log.Field) {
+	l.state.t.Helper()
+	l.log(log.InfoLevel, msg, fields)
+}
+
+func (l *tLogger) Warn(msg string, fields ...log.Field) {
+	l.state.t.Helper()
+	l.log(log.WarnLevel, msg, fields)
+}
+
+func (l *tLogger) Error(msg string, fields ...log.Field) {
+	l.state.t.Helper()
+	l.log(log.ErrorLevel, msg, fields)
+}
+
+func (l *tLogger) WithCallerSkip(skip int) log.Logger {
+	return &tLogger{state: l.state, callerSkip: l.callerSkip + skip}
+}
+
+func (l *tLogger) log(lvl log.Level, msg string, fields []log.Field) {
+	t := l.state.t
+	t.Helper()
+
+	entry := Entry{Level: lvl, Message: msg, Fields: log.FlattenFields(fields)}
+	line := entry.String()
+	if l.callerSkip > 0 {
+		if _, file, n, ok := runtime.Caller(l.callerSkip + 2); ok {
+			line += " (" + filepath.Base(file) + ":" + strconv.Itoa(n) + ")"
+		}
+	}
+
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	if l.state.done {
+		return
+	}
+
+	if lvl == log.ErrorLevel && l.state.failOnError {
+		t.Error(line)
+	} else {
+		t.Log(line)
+	}
+}
@@ -0,0 +1,74 @@
+package logtest_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logtest"
+)
+
+func TestNewT(t *testing.T) {
+	var rt recordingT
+	logger := logtest.NewT(&rt)
+	logger.Info("first call", log.String("foo", "a b"))
+	log.WithFields(logger, log.Int("bar", 1)).Error("second call")
+
+	rt.cleanup()
+	logger.Info("third call")
+
+	want := []string{
+		`INFO first call foo="a b"`,
+		"ERROR second call bar=1 (testing_test.go:17)",
+	}
+	if !reflect.DeepEqual(rt.logs, want) {
+		t.Errorf("got %q; want %q", rt.logs, want)
+	}
+	if rt.failed {
+		t.Error("want the test not to fail")
+	}
+}
+
+func TestNewT_failOnError(t *testing.T) {
+	var rt recordingT
+	logger := logtest.NewT(&rt, logtest.FailOnError(true))
+	logger.Warn("first call")
+	logger.Error("second call")
+
+	want := []string{"WARN first call", "ERROR second call"}
+	if !reflect.DeepEqual(rt.logs, want) {
+		t.Errorf("got %q; want %q", rt.logs, want)
+	}
+	if !rt.failed {
+		t.Error("want the test to fail")
+	}
+}
+
+// recordingT records the calls to the methods used by logtest.NewT.
+type recordingT struct {
+	testing.TB
+	logs     []string
+	failed   bool
+	cleanups []func()
+}
+
+func (rt *recordingT) Helper()                 {}
+func (rt *recordingT) Log(args ...interface{}) { rt.logs = append(rt.logs, fmt.Sprint(args...)) }
+func (rt *recordingT) Cleanup(f func())        { rt.cleanups = append(rt.cleanups, f) }
+
+func (rt *recordingT) Error(args ...interface{}) {
+	rt.Log(args...)
+	rt.failed = true
+}
+
+func (rt *recordingT) Errorf(format string, args ...interface{}) {
+	rt.Error(fmt.Sprintf(format, args...))
+}
+
+func (rt *recordingT) cleanup() {
+	for _, f := range rt.cleanups {
+		f()
+	}
+}
@@ -0,0 +1,175 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NamedLevels is the registry used by named loggers to look up their levels.
+// See Named and LevelRegistry for details.
+var NamedLevels = new(LevelRegistry)
+
+// Named creates a child Logger with the provided name,
+// which is added to each logging operation as the "logger" field.
+// If the provided logger (or any of its WithFields, WithHooks or WithLevel parents) is already named,
+// the names are joined with a dot, e.g. Named(Named(logger, "billing"), "invoices") is named "billing.invoices".
+// The child drops logging operations below the level configured for its name in NamedLevels.
+func Named(logger Logger, name string) Logger {
+	switch l := logger.(type) {
+	case *named:
+		return &named{logger: l.logger, name: l.name + "." + name}
+	case *withFields:
+		return &withFields{logger: Named(l.logger, name), fields: l.fields}
+	case *withHooks:
+		return &withHooks{logger: Named(l.logger, name), hooks: l.hooks}
+	case *withLevel:
+		return &withLevel{logger: Named(l.logger, name), min: l.min}
+	}
+
+	return &named{
+		logger: addCallerSkip(logger, 1),
+		name:   name,
+	}
+}
+
+type named struct {
+	logger Logger
+	name   string
+}
+
+func (n *named) Debug(msg string, fields ...Field) {
+	if n.enabled(DebugLevel) {
+		n.logger.Debug(msg, n.withName(fields)...)
+	}
+}
+
+func (n *named) Info(msg string, fields ...Field) {
+	if n.enabled(InfoLevel) {
+		n.logger.Info(msg, n.withName(fields)...)
+	}
+}
+
+func (n *named) Warn(msg string, fields ...Field) {
+	if n.enabled(WarnLevel) {
+		n.logger.Warn(msg, n.withName(fields)...)
+	}
+}
+
+func (n *named) Error(msg string, fields ...Field) {
+	if n.enabled(ErrorLevel) {
+		n.logger.Error(msg, n.withName(fields)...)
+	}
+}
+
+func (n *named) WithCallerSkip(skip int) Logger {
+	return &named{logger: addCallerSkip(n.logger, skip), name: n.name}
+}
+
+func (n *named) Enabled(lvl Level) bool { return n.enabled(lvl) && Enabled(n.logger, lvl) }
+
+func (n *named) Unwrap() Logger { return n.logger }
+
+func (n *named) enabled(lvl Level) bool { return lvl >= NamedLevels.Level(n.name) }
+
+func (n *named) withName(fields []Field) []Field {
+	return append([]Field{String("logger", n.name)}, fields...)
+}
+
+// LevelRegistry holds levels configured for named loggers.
+// A level is configured for a dotted name prefix and applies to all loggers under it,
+// the most specific prefix wins. The special "*" name sets the default level.
+// If no level matches a name, DEBUG level is used, i.e. nothing is dropped.
+// Looking up a level requires no locks, so it can be safely reconfigured at runtime.
+type LevelRegistry struct {
+	mu     sync.Mutex   // serializes writers.
+	levels atomic.Value // map[string]Level, replaced on each write.
+}
+
+// Level returns the level configured for the provided name.
+func (r *LevelRegistry) Level(name string) Level {
+	levels, _ := r.levels.Load().(map[string]Level)
+	if len(levels) == 0 {
+		return DebugLevel
+	}
+
+	for {
+		if lvl, ok := levels[name]; ok {
+			return lvl
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	if lvl, ok := levels["*"]; ok {
+		return lvl
+	}
+
+	return DebugLevel
+}
+
+// Set configures the level for the provided name prefix.
+func (r *LevelRegistry) Set(name string, lvl Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, _ := r.levels.Load().(map[string]Level)
+	levels := make(map[string]Level, len(old)+1)
+	for k, v := range old {
+		levels[k] = v
+	}
+	levels[name] = lvl
+
+	r.levels.Store(levels)
+}
+
+// Load replaces the whole configuration with the one parsed from the provided string.
+// See ParseLevelConfig for the format.
+func (r *LevelRegistry) Load(config string) error {
+	levels, err := ParseLevelConfig(config)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.levels.Store(levels)
+	return nil
+}
+
+// ParseLevelConfig parses a comma-separated list of "name=level" pairs,
+// e.g. "billing=debug,billing.invoices=error,*=info".
+func ParseLevelConfig(config string) (map[string]Level, error) {
+	levels := make(map[string]Level)
+
+	for _, pair := range strings.Split(config, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		i := strings.IndexByte(pair, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid pair %q: want name=level", pair)
+		}
+
+		name := strings.TrimSpace(pair[:i])
+		if name == "" {
+			return nil, fmt.Errorf("invalid pair %q: empty name", pair)
+		}
+
+		lvl, err := ParseLevel(strings.TrimSpace(pair[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pair %q: %w", pair, err)
+		}
+
+		levels[name] = lvl
+	}
+
+	return levels, nil
+}
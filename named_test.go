@@ -0,0 +1,93 @@
+package log_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/junk1tm/log"
+)
+
+func TestNamed(t *testing.T) {
+	if err := log.NamedLevels.Load("billing=info,billing.invoices=error"); err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	defer func() { _ = log.NamedLevels.Load("") }()
+
+	var spy spyLogger
+	billing := log.Named(&spy, "billing")
+	billing.Debug("first call")
+	billing.Info("second call")
+
+	invoices := log.Named(log.WithFields(billing, log.Int("foo", 1)), "invoices")
+	invoices.Warn("third call")
+	invoices.Error("fourth call")
+	billing.Info("fifth call")
+
+	want := []call{
+		{
+			msg:    "second call",
+			fields: []log.Field{log.String("logger", "billing"), log.String("caller", "named_test.go:19")},
+		},
+		{
+			msg:    "fourth call",
+			fields: []log.Field{log.String("logger", "billing.invoices"), log.Int("foo", 1), log.String("caller", "named_test.go:23")},
+		},
+		{
+			msg:    "fifth call",
+			fields: []log.Field{log.String("logger", "billing"), log.String("caller", "named_test.go:24")},
+		},
+	}
+	if got := spy.calls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestLevelRegistry(t *testing.T) {
+	var r log.LevelRegistry
+	if got := r.Level("billing"); got != log.DebugLevel {
+		t.Errorf("got %v; want %v", got, log.DebugLevel)
+	}
+
+	if err := r.Load("billing=debug, billing.invoices=error, *=info"); err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	r.Set("billing.invoices.pdf", log.WarnLevel)
+
+	tests := map[string]log.Level{
+		"":                         log.InfoLevel,
+		"payments":                 log.InfoLevel,
+		"billing":                  log.DebugLevel,
+		"billing.refunds":          log.DebugLevel,
+		"billing.invoices":         log.ErrorLevel,
+		"billing.invoices.emails":  log.ErrorLevel,
+		"billing.invoices.pdf":     log.WarnLevel,
+		"billing.invoices.pdf.foo": log.WarnLevel,
+		"billingx":                 log.InfoLevel,
+	}
+	for name, want := range tests {
+		if got := r.Level(name); got != want {
+			t.Errorf("%q: got %v; want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseLevelConfig(t *testing.T) {
+	got, err := log.ParseLevelConfig("billing=debug,billing.invoices=ERROR,,*=info")
+	if err != nil {
+		t.Fatalf("got %v; want no error", err)
+	}
+	want := map[string]log.Level{
+		"billing":          log.DebugLevel,
+		"billing.invoices": log.ErrorLevel,
+		"*":                log.InfoLevel,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	for _, config := range []string{"billing", "=info", "billing=fatal"} {
+		if _, err := log.ParseLevelConfig(config); err == nil {
+			t.Errorf("%q: want an error", config)
+		}
+	}
+}
@@ -16,12 +16,36 @@ func ExampleNewLogger() {
 	logger := stdlogimpl.NewLogger(sl)
 	logger.Debug("example 1", log.Int("foo", 1))
 	logger.Info("example 2", log.Int("bar", 2))
-	logger.Error("example 3", log.Int("baz", 3))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
 
 	// output:
 	// [DEBUG] example 1 foo=1
 	// [INFO] example 2 bar=2
-	// [ERROR] example 3 baz=3
+	// [WARN] example 3 baz=3
+	// [ERROR] example 4 qux=4
+}
+
+func ExampleFieldsFormat() {
+	sl := stdlog.New(os.Stdout, "app: ", 0)
+
+	logger := stdlogimpl.NewLogger(sl, stdlogimpl.FieldsFormat(stdlogimpl.JSON))
+	logger.Info("example 1", log.String("foo", "a b"), log.Object(user{id: 1}))
+
+	logger = stdlogimpl.NewLogger(sl, stdlogimpl.FieldsFormat(stdlogimpl.KeyValue))
+	logger.Info("example 2", log.String("foo", "a b"), log.Object(user{id: 1}))
+
+	// output:
+	// app: [INFO] example 1 {"foo":"a b","user_id":1}
+	// app: [INFO] example 2 foo="a b" user_id=1
+}
+
+type user struct {
+	id int
+}
+
+func (u user) ToLog() []log.Field {
+	return []log.Field{log.Int("user_id", u.id)}
 }
 
 func ExampleUnwrap() {
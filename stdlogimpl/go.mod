@@ -3,3 +3,7 @@ module github.com/junk1tm/log/stdlogimpl
 go 1.17
 
 require github.com/junk1tm/log v0.5.0
+
+// The replace makes the module build against the log package from this repository, since it uses API not yet released.
+// Release step: tag the log module first, then bump the require above to that version, so consumers (who ignore replace) get the API too.
+replace github.com/junk1tm/log => ../
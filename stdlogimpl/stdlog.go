@@ -2,48 +2,174 @@
 package stdlogimpl
 
 import (
-	"fmt"
+	"encoding/json"
 	stdlog "log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/junk1tm/log"
 )
 
+// Format is a format of the fields printed after the message.
+type Format int
+
+const (
+	// KeyValue prints the fields in "key=value" form, values are quoted if needed.
+	KeyValue Format = iota
+	// JSON prints the fields as a JSON object.
+	JSON
+)
+
+// Option configures the Logger created by NewLogger.
+type Option func(*wrapper)
+
+// FieldsFormat sets the format of the fields printed after the message (default: KeyValue).
+func FieldsFormat(format Format) Option { return func(w *wrapper) { w.format = format } }
+
 // NewLogger creates a new log.Logger from the provided stdlog.Logger.
-// It prints the provided fields in "key=value" form after the message.
-// NOTE: The prefix of a standard logger is used to print the logging level,
-// so setting it before calling NewLogger will have no effect.
-func NewLogger(logger *stdlog.Logger) log.Logger {
-	return &wrapper{
+// It prints the logging level in "[LEVEL]" form, then the message, then the provided fields.
+// The prefix and the flags of the standard logger are left untouched,
+// so it can be safely shared with other users.
+func NewLogger(logger *stdlog.Logger, opts ...Option) log.Logger {
+	w := &wrapper{
 		callerSkip: 1 + 1, // default calldepth + this wrapper
 		logger:     logger,
+		format:     KeyValue,
+	}
+	for _, opt := range opts {
+		opt(w)
 	}
+	return w
 }
 
 type wrapper struct {
 	callerSkip int
 	logger     *stdlog.Logger
+	format     Format
 }
 
 func (w *wrapper) Debug(msg string, fields ...log.Field) { w.log("DEBUG", msg, fields) }
 func (w *wrapper) Info(msg string, fields ...log.Field)  { w.log("INFO", msg, fields) }
+func (w *wrapper) Warn(msg string, fields ...log.Field)  { w.log("WARN", msg, fields) }
 func (w *wrapper) Error(msg string, fields ...log.Field) { w.log("ERROR", msg, fields) }
 
-func (w *wrapper) AddCallerSkip(skip int) { w.callerSkip += skip }
+// Enabled always returns true, since the standard log package has no levels.
+func (w *wrapper) Enabled(lvl log.Level) bool { return true }
 
-func (w *wrapper) log(lvl string, msg string, fields []log.Field) {
-	prefix := fmt.Sprintf("[%s] ", lvl)
-	w.logger.SetPrefix(prefix)
+func (w *wrapper) WithCallerSkip(skip int) log.Logger {
+	c := *w
+	c.callerSkip += skip
+	return &c
+}
 
+func (w *wrapper) log(lvl string, msg string, fields []log.Field) {
 	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(lvl)
+	sb.WriteString("] ")
 	sb.WriteString(msg)
-	for _, field := range fields {
-		_, _ = fmt.Fprintf(&sb, " %s=%v", field.Key, field.Value)
+
+	fields = log.FlattenKeys(fields)
+	if len(fields) > 0 {
+		switch w.format {
+		case JSON:
+			writeJSON(&sb, fields)
+		default:
+			writeKeyValue(&sb, fields)
+		}
 	}
 
 	_ = w.logger.Output(w.callerSkip+1, sb.String())
 }
 
+func writeKeyValue(sb *strings.Builder, fields []log.Field) {
+	for _, field := range fields {
+		sb.WriteString(" ")
+		sb.WriteString(field.Key)
+		sb.WriteString("=")
+		sb.WriteString(quote(formatValue(field)))
+	}
+}
+
+func writeJSON(sb *strings.Builder, fields []log.Field) {
+	sb.WriteString(" {")
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		key, _ := json.Marshal(field.Key)
+		sb.Write(key)
+		sb.WriteString(":")
+
+		sb.Write(jsonValue(field))
+	}
+	sb.WriteString("}")
+}
+
+func jsonValue(field log.Field) []byte {
+	switch field.Kind {
+	case log.IntKind, log.Int8Kind, log.Int16Kind, log.Int32Kind, log.Int64Kind,
+		log.UintKind, log.Uint8Kind, log.Uint16Kind, log.Uint32Kind, log.Uint64Kind, log.UintptrKind, log.BoolKind:
+		return []byte(formatValue(field))
+	case log.Float32Kind, log.Float64Kind:
+		// NaN and infinities are not supported by JSON, so they are encoded as strings below.
+		if f := formatValue(field); f != "NaN" && f != "+Inf" && f != "-Inf" {
+			return []byte(f)
+		}
+	case log.StringsKind, log.IntsKind, log.TimesKind, log.AnyKind:
+		if b, err := json.Marshal(field.Interface); err == nil {
+			return b
+		}
+	case log.ErrorKind:
+		if err, _ := field.Interface.(error); err == nil {
+			return []byte("null")
+		}
+	case log.ErrorsKind:
+		b, _ := json.Marshal(errorStrings(field.Interface.([]error)))
+		return b
+	case log.DurationsKind:
+		values := field.Interface.([]time.Duration)
+		formatted := make([]string, len(values))
+		for i, d := range values {
+			formatted[i] = d.String()
+		}
+		b, _ := json.Marshal(formatted)
+		return b
+	}
+
+	b, _ := json.Marshal(formatValue(field))
+	return b
+}
+
+// errorStrings returns the messages of the provided errors, nil errors are kept as nil.
+func errorStrings(errs []error) []interface{} {
+	messages := make([]interface{}, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			messages[i] = err.Error()
+		}
+	}
+	return messages
+}
+
+func formatValue(field log.Field) string {
+	return field.ValueString(time.RFC3339Nano)
+}
+
+// quote quotes the provided value if it is empty or contains spaces, quotes, equals signs or non-printable characters.
+func quote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || !strconv.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
+
 // Unwrap unwraps the provided logger,
 // allowing access to the underlying stdlog.Logger.
 // It returns true on success, false otherwise.
@@ -0,0 +1,43 @@
+package stdlogimpl_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	stdlog "log"
+	"testing"
+
+	"github.com/junk1tm/log"
+
+	"github.com/junk1tm/log/logconformance"
+	"github.com/junk1tm/log/stdlogimpl"
+)
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		return stdlogimpl.NewLogger(stdlog.New(w, "", stdlog.Lshortfile))
+	}, logconformance.Unwrap(func(logger log.Logger) bool {
+		_, ok := stdlogimpl.Unwrap(logger)
+		return ok
+	}))
+}
+
+func TestNilError(t *testing.T) {
+	tests := []struct {
+		format stdlogimpl.Format
+		want   string
+	}{
+		{stdlogimpl.KeyValue, "[ERROR] test error=<nil> errors=\"[a <nil>]\"\n"},
+		{stdlogimpl.JSON, "[ERROR] test {\"error\":null,\"errors\":[\"a\",null]}\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		logger := stdlogimpl.NewLogger(stdlog.New(&buf, "", 0), stdlogimpl.FieldsFormat(tt.format))
+		logger.Error("test", log.Error(nil), log.Errors("errors", []error{errors.New("a"), nil}))
+
+		if got := buf.String(); got != tt.want {
+			t.Errorf("got %q; want %q", got, tt.want)
+		}
+	}
+}
@@ -1,26 +1,131 @@
 package log
 
+import (
+	"errors"
+	"strconv"
+)
+
 // FlattenFields flattens the provided fields slice
 // in case it contains Loggable implementations of any nesting,
 // thus it's guaranteed that the output is always a slice of builtin types.
+// Lazy fields are evaluated, so implementations should call it only for entries that will be written.
+// Each Error field is followed by "<key>_chain" with the messages of the errors it wraps (if any),
+// and by the fields of the errors in the chain implementing Loggable, prefixed with "<key>.", e.g. "error.code".
+// The same goes for each element of an Errors field, using "<key>.<index>" as the key, e.g. "errors.0_chain".
 // This function is intended to be used by Logger implementations
 // that should iterate over FlattenFields(fields) to avoid dealing with Loggable directly.
+// Nested and Namespace fields are kept as is, see FlattenKeys for implementations that don't support nesting.
 // FlattenFields will panic if any of the fields has not been created properly.
 func FlattenFields(fields []Field) []Field {
 	var result []Field
 	for _, field := range fields {
-		if l, ok := field.Value.(Loggable); ok {
-			result = append(result, FlattenFields(l.ToLog())...)
-		} else {
+		switch field.Kind {
+		case ObjectKind:
+			result = append(result, FlattenFields(field.Interface.(Loggable).ToLog())...)
+		case LazyKind:
+			lazy := field.Interface.(func() Field)()
+			lazy.Key = field.Key
+			result = append(result, FlattenFields([]Field{lazy})...)
+		case ErrorKind:
+			result = append(result, field)
+			err, _ := field.Interface.(error)
+			result = append(result, errorChain(field.Key, err)...)
+		case ErrorsKind:
+			result = append(result, field)
+			errs, _ := field.Interface.([]error)
+			for i, err := range errs {
+				result = append(result, errorChain(field.Key+"."+strconv.Itoa(i), err)...)
+			}
+		default:
 			result = append(result, field)
 		}
 	}
 
 	for _, field := range result {
-		if !field.valid {
+		if field.Kind == InvalidKind {
 			panic("log.Field must be created using available functions")
 		}
 	}
 
 	return result
 }
+
+// FlattenKeys is like FlattenFields, but it also replaces Nested and Namespace fields
+// with the fields they contain, adding the key as a dotted prefix, e.g. "user.id".
+// This function is intended to be used by Logger implementations that don't support nesting.
+func FlattenKeys(fields []Field) []Field {
+	return DottedKeys(FlattenFields(fields))
+}
+
+// DottedKeys replaces Nested and Namespace fields of the provided fields, which must be already flattened,
+// with the fields they contain, adding the key as a dotted prefix (see FlattenKeys).
+// It allows rendering the fields returned by FlattenFields without flattening them again,
+// which would duplicate the fields describing error chains.
+func DottedKeys(fields []Field) []Field {
+	return dottedKeys("", fields)
+}
+
+func dottedKeys(prefix string, fields []Field) []Field {
+	var result []Field
+	for _, field := range fields {
+		switch field.Kind {
+		case NestedKind:
+			nested := FlattenFields(field.Interface.(Loggable).ToLog())
+			result = append(result, dottedKeys(prefix+field.Key+".", nested)...)
+		case NamespaceKind:
+			prefix += field.Key + "."
+		default:
+			field.Key = prefix + field.Key
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+// errorChain returns the fields describing the chain of the provided error,
+// which is walked using errors.Unwrap, including multi-errors (implementing Unwrap() []error or Errors() []error):
+// "<key>_chain" with the messages of all the errors in the chain (only if there is more than one),
+// followed by the fields of the errors implementing Loggable, with "<key>." as the dotted prefix (see DottedKeys).
+// If several errors in the chain have a field with the same key, the outermost one is kept.
+func errorChain(key string, err error) []Field {
+	var messages []string
+	var fields []Field
+	seen := make(map[string]bool)
+	walkErrors(err, func(err error) {
+		messages = append(messages, err.Error())
+		if l, ok := err.(Loggable); ok {
+			for _, field := range dottedKeys(key+".", FlattenFields(l.ToLog())) {
+				if !seen[field.Key] {
+					seen[field.Key] = true
+					fields = append(fields, field)
+				}
+			}
+		}
+	})
+
+	if len(messages) > 1 {
+		fields = append([]Field{Strings(key+"_chain", messages)}, fields...)
+	}
+	return fields
+}
+
+// walkErrors calls fn for each error in the chain of the provided error, depth-first.
+func walkErrors(err error, fn func(error)) {
+	for err != nil {
+		fn(err)
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, err := range e.Unwrap() {
+				walkErrors(err, fn)
+			}
+			return
+		case interface{ Errors() []error }:
+			for _, err := range e.Errors() {
+				walkErrors(err, fn)
+			}
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
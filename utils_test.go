@@ -1,6 +1,8 @@
 package log_test
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -28,6 +30,56 @@ func TestFlattenFields(t *testing.T) {
 			fields: []log.Field{log.Int("key_1", 1), log.Object(B{a: A{a: 2}, b: 3})},
 			want:   []log.Field{log.Int("key_1", 1), log.Int("key_2", 2), log.Int("key_3", 3)},
 		},
+		{
+			name: "lazy fields",
+			fields: []log.Field{
+				log.Lazy("key_1", func() log.Field { return log.Int("", 1) }),
+				log.LazyObject(func() log.Loggable { return A{a: 2} }),
+			},
+			want: []log.Field{log.Int("key_1", 1), log.Int("key_2", 2)},
+		},
+		{
+			name:   "error without chain",
+			fields: []log.Field{log.Error(errBase)},
+			want:   []log.Field{log.Error(errBase)},
+		},
+		{
+			name:   "error chain",
+			fields: []log.Field{log.Error(errWrapped)},
+			want: []log.Field{
+				log.Error(errWrapped),
+				log.Strings("error_chain", []string{"op: code 42", "code 42"}),
+				log.Int("error.code", 42),
+			},
+		},
+		{
+			name:   "multi-error",
+			fields: []log.Field{log.Error(errMulti)},
+			want: []log.Field{
+				log.Error(errMulti),
+				log.Strings("error_chain", []string{"2 errors", "base", "op: code 42", "code 42"}),
+				log.Int("error.code", 42),
+			},
+		},
+		{
+			name:   "error fields don't clash with other fields",
+			fields: []log.Field{log.Int("code", 1), log.Error(multiError{codeError{code: 2}, codeError{code: 3}})},
+			want: []log.Field{
+				log.Int("code", 1),
+				log.Error(multiError{codeError{code: 2}, codeError{code: 3}}),
+				log.Strings("error_chain", []string{"2 errors", "code 2", "code 3"}),
+				log.Int("error.code", 2),
+			},
+		},
+		{
+			name:   "errors",
+			fields: []log.Field{log.Errors("errors", []error{errBase, errWrapped})},
+			want: []log.Field{
+				log.Errors("errors", []error{errBase, errWrapped}),
+				log.Strings("errors.1_chain", []string{"op: code 42", "code 42"}),
+				log.Int("errors.1.code", 42),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -42,13 +94,55 @@ func TestFlattenFields(t *testing.T) {
 	t.Run("panic on a manually created Field", func(t *testing.T) {
 		defer func() { _ = recover() }()
 
-		field := log.Field{Key: "foo", Value: "bar"}
+		field := log.Field{Key: "foo", String: "bar"}
 		log.FlattenFields([]log.Field{field})
 
 		t.Error("want FlattenFields to panic")
 	})
 }
 
+func TestFlattenKeys(t *testing.T) {
+	fields := []log.Field{
+		log.Int("key_1", 1),
+		log.Nested("b", B{a: A{a: 2}, b: 3}),
+		log.Namespace("ns"),
+		log.Object(A{a: 4}),
+		log.Int("key_5", 5),
+	}
+	want := []log.Field{
+		log.Int("key_1", 1),
+		log.Int("b.key_2", 2),
+		log.Int("b.key_3", 3),
+		log.Int("ns.key_2", 4),
+		log.Int("ns.key_5", 5),
+	}
+
+	if got := log.FlattenKeys(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if got := log.DottedKeys(log.FlattenFields(fields)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+var (
+	errBase    = errors.New("base")
+	errWrapped = fmt.Errorf("op: %w", codeError{code: 42})
+	errMulti   = multiError{errBase, errWrapped}
+)
+
+// codeError is an error implementing log.Loggable.
+type codeError struct{ code int }
+
+func (e codeError) Error() string      { return fmt.Sprintf("code %d", e.code) }
+func (e codeError) ToLog() []log.Field { return []log.Field{log.Int("code", e.code)} }
+
+// multiError is an error wrapping several errors, like the ones from popular multi-error packages.
+type multiError []error
+
+func (e multiError) Error() string   { return fmt.Sprintf("%d errors", len(e)) }
+func (e multiError) Errors() []error { return e }
+
 type A struct {
 	a int
 }
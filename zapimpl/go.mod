@@ -11,3 +11,7 @@ require (
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
 )
+
+// The replace makes the module build against the log package from this repository, since it uses API not yet released.
+// Release step: tag the log module first, then bump the require above to that version, so consumers (who ignore replace) get the API too.
+replace github.com/junk1tm/log => ../
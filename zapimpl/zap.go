@@ -7,6 +7,7 @@ import (
 
 	"github.com/junk1tm/log"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // NewLogger creates a new log.Logger from the provided zap.Logger.
@@ -20,59 +21,150 @@ type wrapper struct {
 	logger *zap.Logger
 }
 
-func (w *wrapper) Debug(msg string, fields ...log.Field) { w.logger.Debug(msg, zapFields(fields)...) }
-func (w *wrapper) Info(msg string, fields ...log.Field)  { w.logger.Info(msg, zapFields(fields)...) }
-func (w *wrapper) Error(msg string, fields ...log.Field) { w.logger.Error(msg, zapFields(fields)...) }
+// NOTE: the fields are converted only after the level check, so Lazy fields of dropped entries are never evaluated.
+// Check is called directly from each method to keep the number of callers to skip the same as for zap.Logger's methods.
 
-func (w *wrapper) AddCallerSkip(skip int) { w.logger = w.logger.WithOptions(zap.AddCallerSkip(skip)) }
+func (w *wrapper) Debug(msg string, fields ...log.Field) {
+	if ce := w.logger.Check(zapcore.DebugLevel, msg); ce != nil {
+		ce.Write(zapFields(fields)...)
+	}
+}
+
+func (w *wrapper) Info(msg string, fields ...log.Field) {
+	if ce := w.logger.Check(zapcore.InfoLevel, msg); ce != nil {
+		ce.Write(zapFields(fields)...)
+	}
+}
+
+func (w *wrapper) Warn(msg string, fields ...log.Field) {
+	if ce := w.logger.Check(zapcore.WarnLevel, msg); ce != nil {
+		ce.Write(zapFields(fields)...)
+	}
+}
+
+func (w *wrapper) Error(msg string, fields ...log.Field) {
+	if ce := w.logger.Check(zapcore.ErrorLevel, msg); ce != nil {
+		ce.Write(zapFields(fields)...)
+	}
+}
+
+func (w *wrapper) Enabled(lvl log.Level) bool {
+	return w.logger.Core().Enabled(zapLevel(lvl))
+}
+
+func (w *wrapper) WithFields(fields ...log.Field) log.Logger {
+	return &wrapper{logger: w.logger.With(zapFields(fields)...)}
+}
+
+func (w *wrapper) WithCallerSkip(skip int) log.Logger {
+	return &wrapper{logger: w.logger.WithOptions(zap.AddCallerSkip(skip))}
+}
+
+func zapLevel(lvl log.Level) zapcore.Level {
+	switch lvl {
+	case log.DebugLevel:
+		return zapcore.DebugLevel
+	case log.InfoLevel:
+		return zapcore.InfoLevel
+	case log.WarnLevel:
+		return zapcore.WarnLevel
+	case log.ErrorLevel:
+		return zapcore.ErrorLevel
+	default:
+		panic(fmt.Sprintf("unexpected level %v", lvl))
+	}
+}
 
 func zapFields(fields []log.Field) []zap.Field {
 	var zf []zap.Field
 
 	for _, field := range log.FlattenFields(fields) {
-		switch value := field.Value.(type) {
-		case int:
-			zf = append(zf, zap.Int(field.Key, value))
-		case int8:
-			zf = append(zf, zap.Int8(field.Key, value))
-		case int16:
-			zf = append(zf, zap.Int16(field.Key, value))
-		case int32:
-			zf = append(zf, zap.Int32(field.Key, value))
-		case int64:
-			zf = append(zf, zap.Int64(field.Key, value))
-		case uint:
-			zf = append(zf, zap.Uint(field.Key, value))
-		case uint8:
-			zf = append(zf, zap.Uint8(field.Key, value))
-		case uint16:
-			zf = append(zf, zap.Uint16(field.Key, value))
-		case uint32:
-			zf = append(zf, zap.Uint32(field.Key, value))
-		case uint64:
-			zf = append(zf, zap.Uint64(field.Key, value))
-		case float32:
-			zf = append(zf, zap.Float32(field.Key, value))
-		case float64:
-			zf = append(zf, zap.Float64(field.Key, value))
-		case bool:
-			zf = append(zf, zap.Bool(field.Key, value))
-		case string:
-			zf = append(zf, zap.String(field.Key, value))
-		case time.Time:
-			zf = append(zf, zap.Time(field.Key, value))
-		case time.Duration:
-			zf = append(zf, zap.Duration(field.Key, value))
-		case error:
-			zf = append(zf, zap.NamedError(field.Key, value))
+		switch field.Kind {
+		case log.IntKind:
+			zf = append(zf, zap.Int(field.Key, int(field.Integer)))
+		case log.Int8Kind:
+			zf = append(zf, zap.Int8(field.Key, int8(field.Integer)))
+		case log.Int16Kind:
+			zf = append(zf, zap.Int16(field.Key, int16(field.Integer)))
+		case log.Int32Kind:
+			zf = append(zf, zap.Int32(field.Key, int32(field.Integer)))
+		case log.Int64Kind:
+			zf = append(zf, zap.Int64(field.Key, field.Integer))
+		case log.UintKind:
+			zf = append(zf, zap.Uint(field.Key, uint(field.Integer)))
+		case log.Uint8Kind:
+			zf = append(zf, zap.Uint8(field.Key, uint8(field.Integer)))
+		case log.Uint16Kind:
+			zf = append(zf, zap.Uint16(field.Key, uint16(field.Integer)))
+		case log.Uint32Kind:
+			zf = append(zf, zap.Uint32(field.Key, uint32(field.Integer)))
+		case log.Uint64Kind:
+			zf = append(zf, zap.Uint64(field.Key, uint64(field.Integer)))
+		case log.Float32Kind:
+			zf = append(zf, zap.Float32(field.Key, field.Float32()))
+		case log.Float64Kind:
+			zf = append(zf, zap.Float64(field.Key, field.Float64()))
+		case log.BoolKind:
+			zf = append(zf, zap.Bool(field.Key, field.Bool()))
+		case log.StringKind, log.StackKind:
+			zf = append(zf, zap.String(field.Key, field.String))
+		case log.TimeKind:
+			zf = append(zf, zap.Time(field.Key, field.Time()))
+		case log.DurationKind:
+			zf = append(zf, zap.Duration(field.Key, field.Duration()))
+		case log.ErrorKind:
+			// zap skips nil errors, so they are encoded as null values to keep the key.
+			if err, _ := field.Interface.(error); err != nil {
+				zf = append(zf, zap.NamedError(field.Key, err))
+			} else {
+				zf = append(zf, zap.Reflect(field.Key, nil))
+			}
+		case log.ErrorsKind:
+			zf = append(zf, zap.Errors(field.Key, field.Interface.([]error)))
+		case log.StringsKind:
+			zf = append(zf, zap.Strings(field.Key, field.Interface.([]string)))
+		case log.IntsKind:
+			zf = append(zf, zap.Ints(field.Key, field.Interface.([]int)))
+		case log.ByteStringKind:
+			zf = append(zf, zap.ByteString(field.Key, field.Interface.([]byte)))
+		case log.BinaryKind:
+			zf = append(zf, zap.Binary(field.Key, field.Interface.([]byte)))
+		case log.StringerKind:
+			zf = append(zf, zap.Stringer(field.Key, field.Interface.(fmt.Stringer)))
+		case log.UintptrKind:
+			zf = append(zf, zap.Uintptr(field.Key, uintptr(field.Integer)))
+		case log.Complex128Kind:
+			zf = append(zf, zap.Complex128(field.Key, field.Interface.(complex128)))
+		case log.DurationsKind:
+			zf = append(zf, zap.Durations(field.Key, field.Interface.([]time.Duration)))
+		case log.TimesKind:
+			zf = append(zf, zap.Times(field.Key, field.Interface.([]time.Time)))
+		case log.AnyKind:
+			zf = append(zf, zap.Any(field.Key, field.Interface))
+		case log.NestedKind:
+			zf = append(zf, zap.Object(field.Key, objectMarshaler{field.Interface.(log.Loggable)}))
+		case log.NamespaceKind:
+			zf = append(zf, zap.Namespace(field.Key))
 		default:
-			panic(fmt.Sprintf("unexpected field type %T", value))
+			panic(fmt.Sprintf("unexpected field kind %v", field.Kind))
 		}
 	}
 
 	return zf
 }
 
+// objectMarshaler adapts log.Loggable to zapcore.ObjectMarshaler.
+type objectMarshaler struct {
+	loggable log.Loggable
+}
+
+func (m objectMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range zapFields(m.loggable.ToLog()) {
+		field.AddTo(enc)
+	}
+	return nil
+}
+
 // Unwrap unwraps the provided logger,
 // allowing access to the underlying zap.Logger.
 // It returns true on success, false otherwise.
@@ -0,0 +1,113 @@
+package zapimpl_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/junk1tm/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/junk1tm/log/logconformance"
+	"github.com/junk1tm/log/zapimpl"
+)
+
+func TestNested(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}),
+		zapcore.AddSync(&buf),
+		zapcore.DebugLevel,
+	)
+	logger := zapimpl.NewLogger(zap.New(core))
+	logger.Info("nested",
+		log.Nested("user", user{id: 1}),
+		log.Namespace("request"),
+		log.String("method", "GET"),
+	)
+
+	want := `{"msg":"nested","user":{"id":1},"request":{"method":"GET"}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", CallerKey: "caller", EncodeCaller: zapcore.ShortCallerEncoder}),
+		zapcore.AddSync(&buf),
+		zapcore.InfoLevel,
+	)
+	logger := zapimpl.NewLogger(zap.New(core, zap.AddCaller()))
+
+	var evaluated int
+	lazy := log.Lazy("lazy", func() log.Field { evaluated++; return log.Int("", evaluated) })
+	logger.Debug("dropped", lazy)
+	logger.Info("written", lazy)
+
+	want := `{"caller":"zapimpl/zap_test.go:48","msg":"written","lazy":1}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	if enabler := logger.(log.LevelEnabler); enabler.Enabled(log.DebugLevel) || !enabler.Enabled(log.InfoLevel) {
+		t.Error("want DEBUG to be disabled and INFO to be enabled")
+	}
+}
+
+type user struct{ id int }
+
+func (u user) ToLog() []log.Field { return []log.Field{log.Int("id", u.id)} }
+
+func BenchmarkWithFields(b *testing.B) {
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(io.Discard),
+		zapcore.DebugLevel,
+	)
+	logger := zapimpl.NewLogger(zap.New(core))
+
+	fields := []log.Field{
+		log.Int("int", 1),
+		log.String("string", "foo"),
+		log.Bool("bool", true),
+		log.Float64("float64", 1.5),
+	}
+
+	b.Run("native", func(b *testing.B) {
+		logger := log.WithFields(logger, fields...)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			logger.Info("benchmark", log.Int("i", i))
+		}
+	})
+
+	b.Run("generic", func(b *testing.B) {
+		// hide the FieldAdder implementation to force the generic wrapper.
+		logger := log.WithFields(struct{ log.Logger }{logger}, fields...)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			logger.Info("benchmark", log.Int("i", i))
+		}
+	})
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		cfg := zap.NewProductionEncoderConfig()
+		cfg.EncodeTime = zapcore.RFC3339TimeEncoder
+		cfg.EncodeDuration = zapcore.StringDurationEncoder
+		core := zapcore.NewCore(
+			zapcore.NewJSONEncoder(cfg),
+			zapcore.AddSync(w),
+			zapcore.DebugLevel,
+		)
+		return zapimpl.NewLogger(zap.New(core, zap.AddCaller()))
+	}, logconformance.Unwrap(func(logger log.Logger) bool {
+		_, ok := zapimpl.Unwrap(logger)
+		return ok
+	}))
+}
@@ -16,12 +16,14 @@ func ExampleNewLogger() {
 	logger := zerologimpl.NewLogger(zl)
 	logger.Debug("example 1", log.Int("foo", 1))
 	logger.Info("example 2", log.Int("bar", 2))
-	logger.Error("example 3", log.Int("baz", 3))
+	logger.Warn("example 3", log.Int("baz", 3))
+	logger.Error("example 4", log.Int("qux", 4))
 
 	// output:
 	// {"level":"debug","foo":1,"message":"example 1"}
 	// {"level":"info","bar":2,"message":"example 2"}
-	// {"level":"error","baz":3,"message":"example 3"}
+	// {"level":"warn","baz":3,"message":"example 3"}
+	// {"level":"error","qux":4,"message":"example 4"}
 }
 
 func ExampleUnwrap() {
@@ -2,7 +2,9 @@
 package zerologimpl
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/junk1tm/log"
@@ -20,59 +22,162 @@ func NewLogger(logger zerolog.Logger) log.Logger {
 type wrapper struct {
 	callerSkip int
 	logger     zerolog.Logger
+	// namespaced holds the fields added by WithFields starting from the first Namespace, already flattened.
+	// zerolog closes a namespace at the end of the fields it's been opened in,
+	// so these fields are encoded together with the fields of each call instead.
+	namespaced []log.Field
 }
 
 func (w *wrapper) Debug(msg string, fields ...log.Field) { w.log(w.logger.Debug(), msg, fields) }
 func (w *wrapper) Info(msg string, fields ...log.Field)  { w.log(w.logger.Info(), msg, fields) }
+func (w *wrapper) Warn(msg string, fields ...log.Field)  { w.log(w.logger.Warn(), msg, fields) }
 func (w *wrapper) Error(msg string, fields ...log.Field) { w.log(w.logger.Error(), msg, fields) }
 
-func (w *wrapper) AddCallerSkip(skip int) { w.callerSkip += skip }
+func (w *wrapper) WithFields(fields ...log.Field) log.Logger {
+	c := *w
+	flattened := log.FlattenFields(fields)
+	if len(w.namespaced) > 0 {
+		c.namespaced = append(w.namespaced[:len(w.namespaced):len(w.namespaced)], flattened...)
+		return &c
+	}
+
+	for i, field := range flattened {
+		if field.Kind == log.NamespaceKind {
+			flattened, c.namespaced = flattened[:i], flattened[i:]
+			break
+		}
+	}
+	c.logger = w.logger.With().EmbedObject(fieldsMarshaler(flattened)).Logger()
+	return &c
+}
 
+func (w *wrapper) WithCallerSkip(skip int) log.Logger {
+	c := *w
+	c.callerSkip += skip
+	return &c
+}
+
+func (w *wrapper) Enabled(lvl log.Level) bool {
+	zl := zerologLevel(lvl)
+	return zl >= w.logger.GetLevel() && zl >= zerolog.GlobalLevel()
+}
+
+// log checks that the event is enabled before adding the fields, so Lazy fields of dropped entries are never evaluated.
 func (w *wrapper) log(event *zerolog.Event, msg string, fields []log.Field) {
-	for _, field := range log.FlattenFields(fields) {
-		switch value := field.Value.(type) {
-		case int:
-			event.Int(field.Key, value)
-		case int8:
-			event.Int8(field.Key, value)
-		case int16:
-			event.Int16(field.Key, value)
-		case int32:
-			event.Int32(field.Key, value)
-		case int64:
-			event.Int64(field.Key, value)
-		case uint:
-			event.Uint(field.Key, value)
-		case uint8:
-			event.Uint8(field.Key, value)
-		case uint16:
-			event.Uint16(field.Key, value)
-		case uint32:
-			event.Uint32(field.Key, value)
-		case uint64:
-			event.Uint64(field.Key, value)
-		case float32:
-			event.Float32(field.Key, value)
-		case float64:
-			event.Float64(field.Key, value)
-		case bool:
-			event.Bool(field.Key, value)
-		case string:
-			event.Str(field.Key, value)
-		case time.Time:
-			event.Time(field.Key, value)
-		case time.Duration:
-			event.Dur(field.Key, value)
-		case error:
-			event.AnErr(field.Key, value)
+	if !event.Enabled() {
+		return
+	}
+	flattened := log.FlattenFields(fields)
+	if len(w.namespaced) > 0 {
+		flattened = append(w.namespaced[:len(w.namespaced):len(w.namespaced)], flattened...)
+	}
+	eventFields(event, flattened).CallerSkipFrame(w.callerSkip + 1).Msg(msg)
+}
+
+func zerologLevel(lvl log.Level) zerolog.Level {
+	switch lvl {
+	case log.DebugLevel:
+		return zerolog.DebugLevel
+	case log.InfoLevel:
+		return zerolog.InfoLevel
+	case log.WarnLevel:
+		return zerolog.WarnLevel
+	case log.ErrorLevel:
+		return zerolog.ErrorLevel
+	default:
+		panic(fmt.Sprintf("unexpected level %v", lvl))
+	}
+}
+
+// eventFields adds the provided flattened fields to zerolog.Event.
+// Since zerolog has no namespaces, a Namespace field is emulated by a dictionary of all the following fields.
+func eventFields(event *zerolog.Event, fields []log.Field) *zerolog.Event {
+	for i, field := range fields {
+		switch field.Kind {
+		case log.IntKind:
+			event.Int(field.Key, int(field.Integer))
+		case log.Int8Kind:
+			event.Int8(field.Key, int8(field.Integer))
+		case log.Int16Kind:
+			event.Int16(field.Key, int16(field.Integer))
+		case log.Int32Kind:
+			event.Int32(field.Key, int32(field.Integer))
+		case log.Int64Kind:
+			event.Int64(field.Key, field.Integer)
+		case log.UintKind:
+			event.Uint(field.Key, uint(field.Integer))
+		case log.Uint8Kind:
+			event.Uint8(field.Key, uint8(field.Integer))
+		case log.Uint16Kind:
+			event.Uint16(field.Key, uint16(field.Integer))
+		case log.Uint32Kind:
+			event.Uint32(field.Key, uint32(field.Integer))
+		case log.Uint64Kind:
+			event.Uint64(field.Key, uint64(field.Integer))
+		case log.Float32Kind:
+			event.Float32(field.Key, field.Float32())
+		case log.Float64Kind:
+			event.Float64(field.Key, field.Float64())
+		case log.BoolKind:
+			event.Bool(field.Key, field.Bool())
+		case log.StringKind, log.StackKind:
+			event.Str(field.Key, field.String)
+		case log.TimeKind:
+			event.Time(field.Key, field.Time())
+		case log.DurationKind:
+			event.Dur(field.Key, field.Duration())
+		case log.ErrorKind:
+			// zerolog skips nil errors, so they are encoded as null values to keep the key.
+			if err, _ := field.Interface.(error); err != nil {
+				event.AnErr(field.Key, err)
+			} else {
+				event.Interface(field.Key, nil)
+			}
+		case log.ErrorsKind:
+			event.Errs(field.Key, field.Interface.([]error))
+		case log.StringsKind:
+			event.Strs(field.Key, field.Interface.([]string))
+		case log.IntsKind:
+			event.Ints(field.Key, field.Interface.([]int))
+		case log.ByteStringKind:
+			event.Bytes(field.Key, field.Interface.([]byte))
+		case log.BinaryKind:
+			event.Str(field.Key, base64.StdEncoding.EncodeToString(field.Interface.([]byte)))
+		case log.StringerKind:
+			event.Stringer(field.Key, field.Interface.(fmt.Stringer))
+		case log.UintptrKind:
+			event.Uint64(field.Key, uint64(field.Integer))
+		case log.Complex128Kind:
+			event.Str(field.Key, strconv.FormatComplex(field.Interface.(complex128), 'g', -1, 128))
+		case log.DurationsKind:
+			event.Durs(field.Key, field.Interface.([]time.Duration))
+		case log.TimesKind:
+			event.Times(field.Key, field.Interface.([]time.Time))
+		case log.AnyKind:
+			event.Interface(field.Key, field.Interface)
+		case log.NestedKind:
+			event.Dict(field.Key, nestedFields(field))
+		case log.NamespaceKind:
+			return event.Dict(field.Key, eventFields(zerolog.Dict(), fields[i+1:]))
 		default:
-			panic(fmt.Sprintf("unexpected field type %T", value))
+			panic(fmt.Sprintf("unexpected field kind %v", field.Kind))
 		}
 	}
 
-	event.CallerSkipFrame(w.callerSkip + 1).Msg(msg)
+	return event
+}
+
+// nestedFields encodes the fields of the provided Nested field as a zerolog dictionary.
+func nestedFields(field log.Field) *zerolog.Event {
+	return eventFields(zerolog.Dict(), log.FlattenFields(field.Interface.(log.Loggable).ToLog()))
 }
 
+// fieldsMarshaler encodes flattened fields as a zerolog.LogObjectMarshaler,
+// which allows embedding them into zerolog.Context, so they are encoded only once.
+type fieldsMarshaler []log.Field
+
+func (fm fieldsMarshaler) MarshalZerologObject(event *zerolog.Event) { eventFields(event, fm) }
+
 // Unwrap unwraps the provided logger,
 // allowing access to the underlying zerolog.Logger.
 // It returns true on success, false otherwise.
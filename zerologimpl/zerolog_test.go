@@ -0,0 +1,103 @@
+package zerologimpl_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/junk1tm/log"
+	"github.com/rs/zerolog"
+
+	"github.com/junk1tm/log/logconformance"
+	"github.com/junk1tm/log/zerologimpl"
+)
+
+func TestNested(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerologimpl.NewLogger(zerolog.New(&buf))
+	logger = log.WithFields(logger, log.Nested("user", user{id: 1}))
+	logger.Info("nested",
+		log.Namespace("request"),
+		log.String("method", "GET"),
+	)
+
+	want := `{"level":"info","user":{"id":1},"request":{"method":"GET"},"message":"nested"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerologimpl.NewLogger(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	var evaluated int
+	lazy := log.Lazy("lazy", func() log.Field { evaluated++; return log.Int("", evaluated) })
+	logger.Debug("dropped", lazy)
+	logger.Info("written", lazy)
+
+	want := `{"level":"info","lazy":1,"message":"written"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	if enabler := logger.(log.LevelEnabler); enabler.Enabled(log.DebugLevel) || !enabler.Enabled(log.InfoLevel) {
+		t.Error("want DEBUG to be disabled and INFO to be enabled")
+	}
+}
+
+type user struct{ id int }
+
+func (u user) ToLog() []log.Field { return []log.Field{log.Int("id", u.id)} }
+
+func BenchmarkWithFields(b *testing.B) {
+	logger := zerologimpl.NewLogger(zerolog.New(io.Discard))
+
+	fields := []log.Field{
+		log.Int("int", 1),
+		log.String("string", "foo"),
+		log.Bool("bool", true),
+		log.Float64("float64", 1.5),
+	}
+
+	b.Run("native", func(b *testing.B) {
+		logger := log.WithFields(logger, fields...)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			logger.Info("benchmark", log.Int("i", i))
+		}
+	})
+
+	b.Run("generic", func(b *testing.B) {
+		// hide the FieldAdder implementation to force the generic wrapper.
+		logger := log.WithFields(struct{ log.Logger }{logger}, fields...)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			logger.Info("benchmark", log.Int("i", i))
+		}
+	})
+}
+
+func TestConformance(t *testing.T) {
+	logconformance.Run(t, func(w io.Writer) log.Logger {
+		return zerologimpl.NewLogger(zerolog.New(w).With().Caller().Logger())
+	}, logconformance.Unwrap(func(logger log.Logger) bool {
+		_, ok := zerologimpl.Unwrap(logger)
+		return ok
+	}))
+}
+
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerologimpl.NewLogger(zerolog.New(&buf))
+	logger = log.WithFields(logger, log.Int("foo", 1), log.Namespace("ns"), log.Int("bar", 2))
+	logger = log.WithFields(logger, log.Int("baz", 3))
+	logger.Info("with fields", log.Int("qux", 4))
+
+	// the namespace must stay open for the fields added later.
+	want := `{"level":"info","foo":1,"ns":{"bar":2,"baz":3,"qux":4},"message":"with fields"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}